@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import "io/ioutil"
+
+// Analyzer computes cyclomatic complexity of Go source files, configured once
+// through options and reused for any number of files and directories.
+type Analyzer struct {
+	options      Options
+	skippedFiles ParseErrors //Files skipped by the last directory analysis.
+}
+
+// Option configures an Analyzer.
+type Option func(*Analyzer)
+
+// WithIncludePrefix reports only functions with name starting with prefix.
+func WithIncludePrefix(prefix string) Option {
+	return func(analyzer *Analyzer) {
+		analyzer.options.IncludePrefix = prefix
+	}
+}
+
+// WithLimit reports only the limit most complex functions, most complex first.
+func WithLimit(limit int) Option {
+	return func(analyzer *Analyzer) {
+		analyzer.options.Limit = limit
+	}
+}
+
+// WithStrictParse makes directory analysis fail if any file fails to parse, instead of
+// skipping the files failing to parse.
+func WithStrictParse(strict bool) Option {
+	return func(analyzer *Analyzer) {
+		analyzer.options.StrictParse = strict
+	}
+}
+
+// WithGeneratedFiles makes directory analysis include generated files, skipped by default
+// as their large switches and long functions are not maintained by hand.
+func WithGeneratedFiles(include bool) Option {
+	return func(analyzer *Analyzer) {
+		analyzer.options.IncludeGenerated = include
+	}
+}
+
+// WithGoVersion makes analysis reject files using language features newer than Go version
+// goVersion, such as the module's declared "go 1.22", instead of analyzing syntax the module
+// cannot compile.
+func WithGoVersion(goVersion string) Option {
+	return func(analyzer *Analyzer) {
+		analyzer.options.GoVersion = goVersion
+	}
+}
+
+// WithErrorCheckDiscount makes analysis leave the branches of standard if err != nil checks
+// out of the complexity, reporting the complexity of the logic beside the error handling.
+func WithErrorCheckDiscount(discount bool) Option {
+	return func(analyzer *Analyzer) {
+		analyzer.options.DiscountErrors = discount
+	}
+}
+
+// WithConcurrency makes analysis build the control-flow graphs of the functions in each file
+// concurrently, from the syntax tree of the file parsed once, speeding up files with many functions.
+func WithConcurrency(concurrent bool) Option {
+	return func(analyzer *Analyzer) {
+		analyzer.options.Concurrent = concurrent
+	}
+}
+
+// NewAnalyzer returns an Analyzer configured by opts, reporting every function if no options are given.
+func NewAnalyzer(opts ...Option) *Analyzer {
+	analyzer := &Analyzer{}
+	for _, opt := range opts {
+		opt(analyzer)
+	}
+	return analyzer
+}
+
+// AnalyzeFile computes cyclomatic complexity for the functions in the Go source file at srcPath.
+func (analyzer *Analyzer) AnalyzeFile(srcPath string) ([]*FunctionComplexity, error) {
+	srcFile, err := ioutil.ReadFile(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	functions, err := analyzer.analyzeSource(srcPath, srcFile)
+	if err != nil {
+		return nil, err
+	}
+	return analyzer.options.selectFunctions(functions), nil
+}
+
+// AnalyzeDir computes cyclomatic complexity for the functions in the Go source files in dir,
+// not searching subdirectories. Test files and generated files are skipped, and options such
+// as the limit apply to the functions of all files together. Files failing to parse are
+// skipped and reported by SkippedFiles, or with strict parsing make AnalyzeDir return a
+// ParseErrors error listing every such file.
+func (analyzer *Analyzer) AnalyzeDir(dir string) ([]*FunctionComplexity, error) {
+	goFiles, err := getGoFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var functions []*FunctionComplexity
+	analyzer.skippedFiles = ParseErrors{}
+	for _, goFile := range goFiles {
+		srcFile, err := ioutil.ReadFile(goFile)
+		if err != nil {
+			analyzer.skippedFiles[goFile] = err
+			continue
+		}
+		if !analyzer.options.includesSource(srcFile) {
+			continue
+		}
+		fileFunctions, err := analyzer.analyzeSource(goFile, srcFile)
+		if err != nil {
+			analyzer.skippedFiles[goFile] = err
+			continue
+		}
+		functions = append(functions, fileFunctions...)
+	}
+
+	if analyzer.options.StrictParse && len(analyzer.skippedFiles) > 0 {
+		return nil, analyzer.skippedFiles
+	}
+	return analyzer.options.selectFunctions(functions), nil
+}
+
+// analyzeSource computes cyclomatic complexity for each function in srcFile, read from srcPath,
+// first checking srcFile against the Go version of the options if set, and discounting error
+// checks if set. Functions are analyzed concurrently if set.
+func (analyzer *Analyzer) analyzeSource(srcPath string, srcFile []byte) ([]*FunctionComplexity, error) {
+	if analyzer.options.GoVersion != "" {
+		if err := checkGoVersion(srcPath, srcFile, analyzer.options.GoVersion); err != nil {
+			return nil, err
+		}
+	}
+	if analyzer.options.DiscountErrors {
+		discounted, err := discountErrorChecks(srcFile)
+		if err != nil {
+			return nil, err
+		}
+		srcFile = discounted
+	}
+	if analyzer.options.Concurrent {
+		return analyzeSourceConcurrently(srcPath, srcFile)
+	}
+	return analyzeSource(srcPath, srcFile)
+}
+
+// AnalyzeDirDiscountingErrors computes cyclomatic complexity for the functions in the Go source
+// files in dir as AnalyzeDir, leaving standard if err != nil checks out, so the complexity is that
+// of the business logic beside the error handling.
+func AnalyzeDirDiscountingErrors(dir string) ([]FunctionComplexity, error) {
+	functions, err := NewAnalyzer(WithErrorCheckDiscount(true)).AnalyzeDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]FunctionComplexity, len(functions))
+	for index, function := range functions {
+		results[index] = *function
+	}
+	return results, nil
+}
+
+// SkippedFiles returns the files skipped by the last call to AnalyzeDir for failing
+// to parse, with their errors.
+func (analyzer *Analyzer) SkippedFiles() ParseErrors {
+	return analyzer.skippedFiles
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzerAnalyzeFile(t *testing.T) {
+	testCases := []struct {
+		analyzer  *Analyzer
+		functions []FunctionComplexity
+	}{
+		{NewAnalyzer(), []FunctionComplexity{
+			FunctionComplexity{Name: "main", Complexity: 1},
+			FunctionComplexity{Name: "sign", Complexity: 2},
+			FunctionComplexity{Name: "countdown", Complexity: 3},
+			FunctionComplexity{Name: "classify", Complexity: 4},
+			FunctionComplexity{Name: "noop", Complexity: 1},
+		}},
+		{NewAnalyzer(WithLimit(2)), []FunctionComplexity{
+			FunctionComplexity{Name: "classify", Complexity: 4},
+			FunctionComplexity{Name: "countdown", Complexity: 3},
+		}},
+		{NewAnalyzer(WithIncludePrefix("c"), WithLimit(1)), []FunctionComplexity{
+			FunctionComplexity{Name: "classify", Complexity: 4},
+		}},
+		{NewAnalyzer(WithIncludePrefix("s")), []FunctionComplexity{
+			FunctionComplexity{Name: "sign", Complexity: 2},
+		}},
+	}
+
+	for _, testCase := range testCases {
+		functions, err := testCase.analyzer.AnalyzeFile("./testcode/_ranking.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := verifyCyclomaticComplexity(functions, testCase.functions); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAnalyzerAnalyzeDir(t *testing.T) {
+	functions, err := NewAnalyzer(WithLimit(1)).AnalyzeDir("./testcode/packages/alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "gcd", Complexity: 2},
+	}
+	if err := verifyCyclomaticComplexity(functions, correctFunctions); err != nil {
+		t.Error(err)
+	}
+	if functions[0].File != "testcode/packages/alpha/_alpha.go" {
+		t.Errorf("Function gcd should be in file testcode/packages/alpha/_alpha.go, and not %s!\n", functions[0].File)
+	}
+
+	if _, err := NewAnalyzer().AnalyzeDir("./testcode/missing"); err == nil {
+		t.Error("Analysing a missing directory should fail!")
+	}
+}
+
+func TestAnalyzerAnalyzeDirWithoutFunctions(t *testing.T) {
+	for _, analyzer := range []*Analyzer{NewAnalyzer(), NewAnalyzer(WithConcurrency(true))} {
+		functions, err := analyzer.AnalyzeDir("./testcode/packages/doconly")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(functions) != 0 {
+			t.Errorf("Number of functions should be 0, but are %d!\n", len(functions))
+		}
+		if len(analyzer.SkippedFiles()) != 0 {
+			t.Errorf("Number of skipped files should be 0, but are %d!\n", len(analyzer.SkippedFiles()))
+		}
+	}
+}
+
+func TestAnalyzerStrictParse(t *testing.T) {
+	brokenFiles := []string{"testcode/packages/broken/_broken.go", "testcode/packages/broken/_unfinished.go"}
+
+	//Files failing to parse are skipped by default.
+	analyzer := NewAnalyzer()
+	functions, err := analyzer.AnalyzeDir("./testcode/packages/broken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "gcd", Complexity: 2},
+		FunctionComplexity{Name: "identity", Complexity: 1},
+	}
+	if err := verifyCyclomaticComplexity(functions, correctFunctions); err != nil {
+		t.Error(err)
+	}
+	skippedFiles := analyzer.SkippedFiles()
+	if len(skippedFiles) != len(brokenFiles) {
+		t.Errorf("Number of skipped files should be %d, but are %d!\n", len(brokenFiles), len(skippedFiles))
+	}
+	for _, brokenFile := range brokenFiles {
+		if skippedFiles[brokenFile] == nil {
+			t.Errorf("File %s should be skipped!\n", brokenFile)
+		}
+	}
+
+	//Strict parsing fails, listing all files failing to parse.
+	functions, err = NewAnalyzer(WithStrictParse(true)).AnalyzeDir("./testcode/packages/broken")
+	if err == nil {
+		t.Fatalf("Strict parsing should fail, but got %d functions!\n", len(functions))
+	}
+	if _, ok := err.(ParseErrors); !ok {
+		t.Errorf("Error should be of type ParseErrors, and not %T!\n", err)
+	}
+	if !strings.HasPrefix(err.Error(), "2 file(s) failed to parse: ") {
+		t.Errorf("Error message should count 2 files failing to parse, and not %q!\n", err.Error())
+	}
+	for _, brokenFile := range brokenFiles {
+		if !strings.Contains(err.Error(), brokenFile) {
+			t.Errorf("Error message should list file %s, and not %q!\n", brokenFile, err.Error())
+		}
+	}
+}
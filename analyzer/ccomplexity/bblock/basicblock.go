@@ -8,8 +8,12 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"io"
+	"io/ioutil"
 	"log"
 	"sort"
+	"strings"
 )
 
 type BasicBlockType int
@@ -27,9 +31,17 @@ const (
 	FOR_STATEMENT
 	RANGE_STATEMENT
 	GO_STATEMENT
+	DEFER_STATEMENT
+	GOTO_STATEMENT
+	LABELED_STMT
+	BREAK_STMT
+	CONTINUE_STMT
 	CALL_EXPRESSION
 	ELSE_BODY
 	FOR_BODY
+	IF_BODY
+	RECOVER_CALL
+	STATEMENT
 	EMPTY
 	START
 	EXIT
@@ -48,15 +60,49 @@ var basicBlockTypeStrings = [...]string{
 	FOR_STATEMENT:    "FOR_STATEMENT",
 	RANGE_STATEMENT:  "RANGE_STATEMENT",
 	GO_STATEMENT:     "GO_STATEMENT",
+	DEFER_STATEMENT:  "DEFER_STATEMENT",
+	GOTO_STATEMENT:   "GOTO_STATEMENT",
+	LABELED_STMT:     "LABELED_STMT",
+	BREAK_STMT:       "BREAK_STMT",
+	CONTINUE_STMT:    "CONTINUE_STMT",
 	CALL_EXPRESSION:  "CALL_EXPRESSION",
 	ELSE_BODY:        "ELSE_BODY",
 	FOR_BODY:         "FOR_BODY",
+	IF_BODY:          "IF_BODY",
+	RECOVER_CALL:     "RECOVER_CALL",
+	STATEMENT:        "STATEMENT",
 	EMPTY:            "EMPTY",
 	START:            "START",
 	EXIT:             "EXIT",
 	UNKNOWN:          "UNKNOWN",
 }
 
+// Mode controls the granularity of the basic-blocks built by GetBasicBlocksFromSourceCodeWithMode,
+// and the information kept in them.
+type Mode uint
+
+//Basic Block modes.
+const (
+	STATEMENT_BLOCKS Mode = 1 << iota //Give each statement line its own basic-block.
+	BLOCK_COMMENTS                    //Parse comments, setting the Comment of each basic-block.
+)
+
+//Edge labels.
+const (
+	TRUE_EDGE       = "true"
+	FALSE_EDGE      = "false"
+	SEQUENTIAL_EDGE = "sequential" //Fall-through to the next block in source order.
+	LOOP_EDGE       = "loop"       //From a loop header into the loop body.
+	EXIT_EDGE       = "exit"       //From a loop header past the loop.
+	DEFAULT_EDGE    = "default"    //From a switch statement to its default clause.
+)
+
+// SuccessorEdge is an outgoing edge of a basic-block.
+type SuccessorEdge struct {
+	Target *BasicBlock //Successor block.
+	Label  string      //Edge label, as TRUE_EDGE or the case expressions of a case clause, empty if unlabeled.
+}
+
 func (bbType BasicBlockType) String() string {
 	return basicBlockTypeStrings[bbType]
 }
@@ -66,6 +112,9 @@ func (basicBlock *BasicBlock) UID() string {
 	if basicBlock.Type == START || basicBlock.Type == EXIT {
 		return fmt.Sprintf("%d", 0-basicBlock.Type)
 	}
+	if statement := basicBlock.key & lineKeyMask; statement != 0 {
+		return fmt.Sprintf("%d.%d", basicBlock.EndLine, statement) //One of several blocks on EndLine.
+	}
 	return fmt.Sprintf("%d", basicBlock.EndLine)
 }
 
@@ -80,13 +129,105 @@ func (basicBlock *BasicBlock) String() string {
 
 func (basicBlock *BasicBlock) AddSuccessorBlock(successorBlocks ...*BasicBlock) {
 	for _, successorBlock := range successorBlocks {
-		basicBlock.successor[successorBlock.EndLine] = successorBlock
+		basicBlock.successor[successorBlock.key] = successorBlock
 		basicBlock.LastSuccessor = successorBlock
 	}
 }
 
+// addLabeledSuccessorBlock adds successorBlock as successor, tagging the edge with label.
+func (basicBlock *BasicBlock) addLabeledSuccessorBlock(label string, successorBlock *BasicBlock) {
+	basicBlock.AddSuccessorBlock(successorBlock)
+	basicBlock.successorLabel[successorBlock.key] = label
+}
+
+// getLabeledSuccessorBlock returns the successor block which edge is tagged with label, or nil.
+func (basicBlock *BasicBlock) getLabeledSuccessorBlock(label string) *BasicBlock {
+	for key, successorLabel := range basicBlock.successorLabel {
+		if successorLabel == label {
+			return basicBlock.successor[key]
+		}
+	}
+	return nil
+}
+
+// TrueSuccessor returns the block entered when the condition of an IF_CONDITION
+// block evaluates to true, or nil if the block has no such edge.
+func (basicBlock *BasicBlock) TrueSuccessor() *BasicBlock {
+	return basicBlock.getLabeledSuccessorBlock(TRUE_EDGE)
+}
+
+// FalseSuccessor returns the block entered when the condition of an IF_CONDITION
+// block evaluates to false, or nil if the block has no such edge.
+func (basicBlock *BasicBlock) FalseSuccessor() *BasicBlock {
+	return basicBlock.getLabeledSuccessorBlock(FALSE_EDGE)
+}
+
+// FallthroughSuccessor returns the next block in source order entered without
+// branching, and true, or nil and false if the block only has branch targets.
+func (basicBlock *BasicBlock) FallthroughSuccessor() (*BasicBlock, bool) {
+	successorBlock := basicBlock.getLabeledSuccessorBlock(SEQUENTIAL_EDGE)
+	return successorBlock, successorBlock != nil
+}
+
+// CallsRecover reports whether the basic-block calls the built-in recover(), making
+// the function either recover from a panic or let the panic continue.
+func (basicBlock *BasicBlock) CallsRecover() bool {
+	return basicBlock.recovers
+}
+
+// ImplicitReturn reports whether the basic-block returns at the closing brace of its
+// function, which has no return statement ending the body.
+func (basicBlock *BasicBlock) ImplicitReturn() bool {
+	return basicBlock.implicit
+}
+
 func NewBasicBlock(blockNumber int, blockType BasicBlockType, endLine int) *BasicBlock {
-	return &BasicBlock{Number: blockNumber, Type: blockType, EndLine: endLine, successor: map[int]*BasicBlock{}}
+	return &BasicBlock{Number: blockNumber, Type: blockType, StartLine: endLine, EndLine: endLine, key: endLine << lineKeyShift,
+		successor: map[int]*BasicBlock{}, successorLabel: map[int]string{}, predecessor: map[int]*BasicBlock{}}
+}
+
+// SuccessorCount returns the number of successor blocks, without
+// the allocation and sorting done by GetSuccessorBlocks.
+func (basicBlock *BasicBlock) SuccessorCount() int {
+	return len(basicBlock.successor)
+}
+
+// GetPredecessorBlocks returns the blocks having this block as successor, sorted by EndLine.
+// Predecessors are set when all successors are linked, by GetBasicBlocksFromSourceCode.
+func (basicBlock *BasicBlock) GetPredecessorBlocks() []*BasicBlock {
+	keys := make([]int, 0, len(basicBlock.predecessor))
+	for k := range basicBlock.predecessor {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	basicBlocks := []*BasicBlock{}
+	for _, key := range keys {
+		basicBlocks = append(basicBlocks, basicBlock.predecessor[key])
+	}
+	return basicBlocks
+}
+
+// linkPredecessors sets the predecessors of the basic-blocks from their successors.
+func linkPredecessors(basicBlocks []*BasicBlock) {
+	for _, bBlock := range basicBlocks {
+		bBlock.predecessor = map[int]*BasicBlock{}
+	}
+	for _, bBlock := range basicBlocks {
+		for _, successor := range bBlock.successor {
+			successor.predecessor[bBlock.key] = bBlock
+		}
+	}
+}
+
+// SuccessorUIDs returns the UIDs of the successor blocks, in the order of GetSuccessorBlocks.
+// UIDs are unique within the blocks of a single function.
+func (basicBlock *BasicBlock) SuccessorUIDs() []string {
+	uids := []string{}
+	for _, successor := range basicBlock.GetSuccessorBlocks() {
+		uids = append(uids, successor.UID())
+	}
+	return uids
 }
 
 func (basicBlock *BasicBlock) GetSuccessorBlocks() []*BasicBlock {
@@ -107,13 +248,36 @@ func (basicBlock *BasicBlock) GetSuccessorBlocks() []*BasicBlock {
 	return basicBlocks
 }
 
+// GetSuccessorEdges returns the outgoing edges to the successor blocks, in the order of
+// GetSuccessorBlocks. Edges from IF_CONDITION blocks are labeled TRUE_EDGE or FALSE_EDGE,
+// from FOR_STATEMENT blocks LOOP_EDGE or EXIT_EDGE, and from SWITCH_STATEMENT blocks to
+// case clauses with the case expressions as written, as in "1, 2", or DEFAULT_EDGE.
+func (basicBlock *BasicBlock) GetSuccessorEdges() []SuccessorEdge {
+	edges := []SuccessorEdge{}
+	for _, successor := range basicBlock.GetSuccessorBlocks() {
+		edges = append(edges, SuccessorEdge{Target: successor, Label: basicBlock.successorLabel[successor.key]})
+	}
+	return edges
+}
+
 type BasicBlock struct {
-	Number        int
-	Type          BasicBlockType
-	EndLine       int
-	LastSuccessor *BasicBlock
-	successor     map[int]*BasicBlock
-	FunctionName  string
+	Number         int
+	Type           BasicBlockType
+	StartLine      int //First line, before EndLine when the block spans several lines.
+	StartColumn    int //Column on StartLine, 0 if unknown.
+	EndLine        int
+	EndColumn      int //Column on EndLine, 0 if unknown.
+	LastSuccessor  *BasicBlock
+	key            int //Source order of the block in its function, keying the maps below, see visitor.key.
+	successor      map[int]*BasicBlock
+	successorLabel map[int]string //Edge labels, keyed as successor.
+	predecessor    map[int]*BasicBlock
+	FunctionName   string //Function of a FUNCTION_ENTRY block, receiver-qualified for methods.
+	Comment        string //Text of the comments on EndLine, joined by spaces. Set in BLOCK_COMMENTS mode only.
+	Depth          int    //Nesting depth in control structures, 0 in the function body.
+	recovers       bool   //Block calls recover(), the panic may continue or be recovered.
+	panics         bool   //Block calls panic(), control may leave the function.
+	implicit       bool   //Block returns at the closing brace, without return statement.
 }
 
 type visitor struct {
@@ -122,10 +286,53 @@ type visitor struct {
 
 	lastBlock *BasicBlock
 
-	returnBlock  *BasicBlock
-	forBlock     *BasicBlock
-	forBodyBlock *BasicBlock
-	switchBlock  *BasicBlock
+	returnBlock   *BasicBlock
+	forBodyBlock  *BasicBlock
+	switchBlocks  []*BasicBlock   //Enclosing switch and select statements, innermost last.
+	branchTargets []*branchTarget //Enclosing loops, switches and selects, innermost last.
+	label         string          //Label of the statement being visited, empty if unlabeled.
+
+	fallthroughBlock *BasicBlock //Case clause falling through to the next case clause, nil if none.
+
+	functionName string                 //Name of the function being visited.
+	deferBlocks  []*BasicBlock          //Deferred calls in function being visited, in source order.
+	labelBlocks  map[string]*BasicBlock //Blocks starting labeled statements in function being visited, keyed by label.
+	gotos        []*gotoStmt            //Goto statements in function being visited, resolved after the visit.
+	funcLitCount int                    //Number of function literals found in function being visited.
+	funcLits     []*funcLit             //Function literals found, analysed as separate functions.
+
+	recoverTested   bool        //Result of recover() in statement being visited is tested by the following if statement.
+	statementBlocks bool        //Each statement line is a basic-block, see STATEMENT_BLOCKS.
+	depth           int         //Nesting depth of the statements being visited.
+	statementStarts []token.Pos //Statements starting a line of their own in function being visited, see key.
+}
+
+//Block keys hold the line of the block, shifted by lineKeyShift, and the number of the statement
+//on the line when the statements of a function share lines.
+const (
+	lineKeyShift = 16
+	lineKeyMask  = 1<<lineKeyShift - 1
+)
+
+// gotoStmt is a goto statement, jumping from block to the statement labeled label.
+type gotoStmt struct {
+	block *BasicBlock
+	label string
+}
+
+// branchTarget is an enclosing loop, switch or select statement, targeted by the
+// break and continue statements inside it.
+type branchTarget struct {
+	label         string      //Label of the statement, empty if unlabeled.
+	continueBlock *BasicBlock //Loop header continued by continue, nil for switch and select.
+	breakBlock    *BasicBlock //Block following the statement, nil if none.
+}
+
+// funcLit is a function literal found inside a function, named after the
+// enclosing function as in main$func1.
+type funcLit struct {
+	name string
+	node *ast.FuncLit
 }
 
 // UpdateBasicBlock updates all the variables from the newBasicBlock into the basicBlock object.
@@ -133,26 +340,50 @@ func (basicBlock *BasicBlock) UpdateBasicBlock(newBasicBlock *BasicBlock) {
 	if newBasicBlock != nil {
 		basicBlock.Number = newBasicBlock.Number
 		basicBlock.Type = newBasicBlock.Type
+		basicBlock.StartLine = newBasicBlock.StartLine
+		basicBlock.StartColumn = newBasicBlock.StartColumn
 		basicBlock.EndLine = newBasicBlock.EndLine
+		basicBlock.EndColumn = newBasicBlock.EndColumn
 		basicBlock.LastSuccessor = newBasicBlock.LastSuccessor
+		basicBlock.key = newBasicBlock.key
 		basicBlock.successor = newBasicBlock.successor
+		basicBlock.successorLabel = newBasicBlock.successorLabel
+		basicBlock.predecessor = newBasicBlock.predecessor
 		basicBlock.FunctionName = newBasicBlock.FunctionName
+		basicBlock.Comment = newBasicBlock.Comment
+		basicBlock.Depth = newBasicBlock.Depth
+		basicBlock.recovers = newBasicBlock.recovers
+		basicBlock.panics = newBasicBlock.panics
+		basicBlock.implicit = newBasicBlock.implicit
 	}
 }
 
 func (v *visitor) AddBasicBlock(blockType BasicBlockType, position token.Pos) *BasicBlock {
-	line := v.sourceFileSet.File(position).Line(position)
-	basicBlock := NewBasicBlock(-1, blockType, line) //-1 indicates number will be set later.
+	return v.addKeyedBasicBlock(blockType, position, v.key(position))
+}
+
+// addKeyedBasicBlock adds a basic-block as AddBasicBlock, keyed by key in place of the key of position.
+func (v *visitor) addKeyedBasicBlock(blockType BasicBlockType, position token.Pos, key int) *BasicBlock {
+	sourcePosition := v.sourceFileSet.File(position).Position(position)
+	basicBlock := NewBasicBlock(-1, blockType, sourcePosition.Line) //-1 indicates number will be set later.
+	basicBlock.key = key
+	basicBlock.StartColumn, basicBlock.EndColumn = sourcePosition.Column, sourcePosition.Column
+	basicBlock.Depth = v.depth
 
 	v.lastBlock = basicBlock //Bookkeeping
 
 	//Update the existing block., or add new block.
-	if bb, ok := v.basicBlocks[line]; ok {
+	if bb, ok := v.basicBlocks[key]; ok {
+		//A line shared by a statement and a body nested in it, as a loop header and its body, has
+		//the depth of the statement.
+		if bb.Depth < basicBlock.Depth {
+			basicBlock.Depth = bb.Depth
+		}
 		bb.UpdateBasicBlock(basicBlock)
 		v.lastBlock = bb
 		return bb
 	} else {
-		v.basicBlocks[line] = basicBlock
+		v.basicBlocks[key] = basicBlock
 	}
 	return basicBlock
 }
@@ -161,7 +392,7 @@ func (v *visitor) AddBasicBlock(blockType BasicBlockType, position token.Pos) *B
 // of basic-blocks, in right order!
 func (v *visitor) GetBasicBlocks() []*BasicBlock {
 	keys := make([]int, len(v.basicBlocks))
-	basicBlocks := make([]*BasicBlock, len(v.basicBlocks))
+	basicBlocks := make([]*BasicBlock, 0, len(v.basicBlocks))
 
 	i := 0
 	for k := range v.basicBlocks {
@@ -171,35 +402,251 @@ func (v *visitor) GetBasicBlocks() []*BasicBlock {
 	sort.Ints(keys) //Sort keys from map.
 
 	//Add the basic-block into the array.
-	for index, key := range keys {
-		basicBlocks[index] = v.basicBlocks[key]
-		basicBlocks[index].Number = index //Set basic-block number.
+	for _, key := range keys {
+		basicBlocks = append(basicBlocks, v.basicBlocks[key])
+	}
+	for index, basicBlock := range basicBlocks {
+		basicBlock.Number = index //Set basic-block number.
 	}
 	return basicBlocks
 }
 
 func GetBasicBlocksFromSourceCode(srcFile []byte) ([]*BasicBlock, error) {
+	return GetBasicBlocksFromSourceCodeWithMode(srcFile, 0)
+}
+
+// GetBasicBlocksFromSourceCodeWithMode returns the basic-blocks of srcFile as
+// GetBasicBlocksFromSourceCode, with the granularity given by mode.
+func GetBasicBlocksFromSourceCodeWithMode(srcFile []byte, mode Mode) ([]*BasicBlock, error) {
+	return getBasicBlocks("", srcFile, mode)
+}
+
+// GetBasicBlocksFromReader returns the basic-blocks of the Go source read from r as
+// GetBasicBlocksFromSourceCode. The source is read fully before it is parsed.
+func GetBasicBlocksFromReader(r io.Reader) ([]*BasicBlock, error) {
+	return GetBasicBlocksFromNamedReader("", r)
+}
+
+// GetBasicBlocksFromNamedReader returns the basic-blocks of the Go source read from r as
+// GetBasicBlocksFromReader, reporting parse errors at positions in the file filename.
+func GetBasicBlocksFromNamedReader(filename string, r io.Reader) ([]*BasicBlock, error) {
+	srcFile, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return getBasicBlocks(filename, srcFile, 0)
+}
+
+// getBasicBlocks returns the basic-blocks of srcFile named filename, with the granularity
+// given by mode.
+func getBasicBlocks(filename string, srcFile []byte, mode Mode) ([]*BasicBlock, error) {
 	fileSet := token.NewFileSet()
-	file, err := parser.ParseFile(fileSet, "", srcFile, 0)
+	parserMode := parser.Mode(0)
+	if mode&BLOCK_COMMENTS != 0 {
+		parserMode = parser.ParseComments
+	}
+	file, err := parseSource(fileSet, filename, srcFile, parserMode)
 	if err != nil {
 		return nil, err
 	}
 
-	visitor := &visitor{sourceFileSet: fileSet, basicBlocks: make(map[int]*BasicBlock)}
+	visitor := &visitor{sourceFileSet: fileSet, basicBlocks: make(map[int]*BasicBlock),
+		statementBlocks: mode&STATEMENT_BLOCKS != 0}
 	ast.Walk(visitor, file)
 
-	basicBlocks := visitor.GetBasicBlocks()
+	basicBlocks := visitor.getLinkedBasicBlocks()
+	for index, bBlock := range basicBlocks {
+		bBlock.Number = index //Function literals are appended, renumber all.
+	}
+	linkPredecessors(basicBlocks)
+	if mode&BLOCK_COMMENTS != 0 {
+		setComments(fileSet, file, basicBlocks)
+	}
+	return basicBlocks, nil
+}
 
-	numberOfBasicBlocks := len(basicBlocks)
+// setComments sets the Comment of each basic-block to the text of the comments in file on
+// the line the block ends, in source order. Comment markers and surrounding space are removed.
+func setComments(fileSet *token.FileSet, file *ast.File, basicBlocks []*BasicBlock) {
+	lineComments := map[int][]string{}
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			line := fileSet.Position(comment.Pos()).Line
+			lineComments[line] = append(lineComments[line], commentText(comment))
+		}
+	}
+	for _, bBlock := range basicBlocks {
+		bBlock.Comment = strings.Join(lineComments[bBlock.EndLine], " ")
+	}
+}
+
+// commentText returns the text of comment without the comment markers.
+func commentText(comment *ast.Comment) string {
+	text := comment.Text
+	if strings.HasPrefix(text, "//") {
+		text = text[2:]
+	} else {
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	}
+	return strings.TrimSpace(text)
+}
+
+// GetBasicBlocksFromFuncDecl returns the basic-blocks of funcDecl, parsed into fileSet,
+// followed by the basic-blocks of every function literal in it, numbered from zero. The
+// syntax tree is only read, so the declarations of a file parsed once can be analysed
+// concurrently.
+func GetBasicBlocksFromFuncDecl(fileSet *token.FileSet, funcDecl *ast.FuncDecl) []*BasicBlock {
+	visitor := &visitor{sourceFileSet: fileSet, basicBlocks: make(map[int]*BasicBlock)}
+	ast.Walk(visitor, funcDecl)
+
+	basicBlocks := visitor.getLinkedBasicBlocks()
 	for index, bBlock := range basicBlocks {
-		if bBlock.Type != FOR_BODY && bBlock.Type != ELSE_CONDITION && bBlock.Type != ELSE_BODY && bBlock.Type != COMM_CLAUSE && bBlock.Type != CASE_CLAUSE && bBlock.Type != RETURN_STMT {
-			if numberOfBasicBlocks > index+1 {
-				bBlock.AddSuccessorBlock(basicBlocks[index+1])
+		bBlock.Number = index
+	}
+	linkPredecessors(basicBlocks)
+	return basicBlocks
+}
+
+// GetFunctionBasicBlocksFromSourceCode returns the basic-blocks of srcFile as
+// GetBasicBlocksFromSourceCode, grouped by function and numbered from zero in each
+// function. Methods are keyed by receiver-qualified name, as (*T).Method for pointer
+// receivers and T.Method for value receivers. Function literals are keyed after their
+// enclosing function, as in main$func1, and main$func1$func1 for a function literal inside it.
+func GetFunctionBasicBlocksFromSourceCode(srcFile []byte) (map[string][]*BasicBlock, error) {
+	basicBlocks, err := GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := map[string][]*BasicBlock{}
+	functionName := ""
+	for _, bBlock := range basicBlocks {
+		if bBlock.Type == FUNCTION_ENTRY {
+			functionName = bBlock.FunctionName
+		}
+		bBlock.Number = len(functions[functionName])
+		functions[functionName] = append(functions[functionName], bBlock)
+	}
+	return functions, nil
+}
+
+// GetBasicBlocksForFunction returns the basic-blocks of the function funcName in srcFile,
+// numbered from zero. Methods are named by method name or by receiver-qualified name,
+// as (*T).Method for pointer receivers and T.Method for value receivers.
+func GetBasicBlocksForFunction(srcFile []byte, funcName string) ([]*BasicBlock, error) {
+	fileSet := token.NewFileSet()
+	file, err := parseSource(fileSet, "", srcFile, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	funcLine := 0
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil &&
+			(funcDecl.Name.Name == funcName || QualifiedFuncName(funcDecl) == funcName) {
+			funcLine = fileSet.Position(funcDecl.Pos()).Line
+			break
+		}
+	}
+	if funcLine == 0 {
+		return nil, fmt.Errorf("no function %s", funcName)
+	}
+
+	basicBlocks, err := GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	functionBlocks := []*BasicBlock{}
+	for _, bBlock := range basicBlocks {
+		if bBlock.Type == FUNCTION_ENTRY {
+			if len(functionBlocks) > 0 {
+				break //Blocks of the next function.
 			}
+			if bBlock.EndLine != funcLine {
+				continue
+			}
+		} else if len(functionBlocks) == 0 {
+			continue
 		}
+		bBlock.Number = len(functionBlocks)
+		functionBlocks = append(functionBlocks, bBlock)
 	}
+	return functionBlocks, nil
+}
 
-	return basicBlocks, nil
+// QualifiedFuncName returns the name of funcDecl, qualified by the receiver type for
+// methods, as (*T).Method for pointer receivers and T.Method for value receivers.
+func QualifiedFuncName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	if _, pointer := ast.Unparen(funcDecl.Recv.List[0].Type).(*ast.StarExpr); pointer {
+		return fmt.Sprintf("(*%s).%s", ReceiverTypeName(funcDecl.Recv), funcDecl.Name.Name)
+	}
+	return fmt.Sprintf("%s.%s", ReceiverTypeName(funcDecl.Recv), funcDecl.Name.Name)
+}
+
+// ReceiverTypeName returns the name of the receiver type in recv, without pointer and
+// type parameters.
+func ReceiverTypeName(recv *ast.FieldList) string {
+	expr := recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// getLinkedBasicBlocks returns the ordered set of basic-blocks found by the visitor
+// with successors linked, followed by the basic-blocks of every function literal found.
+func (v *visitor) getLinkedBasicBlocks() []*BasicBlock {
+	basicBlocks := v.GetBasicBlocks()
+	linkBasicBlocks(basicBlocks)
+
+	for _, lit := range v.funcLits {
+		litVisitor := &visitor{sourceFileSet: v.sourceFileSet, basicBlocks: make(map[int]*BasicBlock),
+			statementBlocks: v.statementBlocks}
+		litVisitor.visitFunction(lit.name, lit.node.Pos(), lit.node.Body)
+		basicBlocks = append(basicBlocks, litVisitor.getLinkedBasicBlocks()...)
+	}
+	return basicBlocks
+}
+
+// linkBasicBlocks adds the successor edge from each basic-block to the next
+// basic-block in sequence, for blocks not ending in a jump.
+func linkBasicBlocks(basicBlocks []*BasicBlock) {
+	numberOfBasicBlocks := len(basicBlocks)
+	for index, bBlock := range basicBlocks {
+		if bBlock.Type != FOR_BODY && bBlock.Type != IF_BODY && bBlock.Type != ELSE_CONDITION && bBlock.Type != ELSE_BODY && bBlock.Type != COMM_CLAUSE && bBlock.Type != CASE_CLAUSE && bBlock.Type != SELECT_STATEMENT && bBlock.Type != RETURN_STMT && bBlock.Type != DEFER_STATEMENT && !isJump(bBlock.Type) {
+			next := index + 1
+			for next < numberOfBasicBlocks && basicBlocks[next].Type == DEFER_STATEMENT {
+				next++ //Deferred calls are entered on return only.
+			}
+			if numberOfBasicBlocks > next {
+				if bBlock.Type == IF_CONDITION {
+					//Next block in sequence is the first block of the if-body.
+					bBlock.addLabeledSuccessorBlock(TRUE_EDGE, basicBlocks[next])
+				} else if isLoop(bBlock.Type) {
+					//Next block in sequence is the first block of the loop body.
+					bBlock.addLabeledSuccessorBlock(LOOP_EDGE, basicBlocks[next])
+				} else if _, labeled := bBlock.successorLabel[basicBlocks[next].key]; !labeled {
+					//A switch statement branches to its first case clause.
+					bBlock.addLabeledSuccessorBlock(SEQUENTIAL_EDGE, basicBlocks[next])
+				}
+			}
+		}
+	}
 }
 
 func PrintBasicBlocks(basicBlocks []*BasicBlock) {
@@ -212,7 +659,8 @@ func PrintBasicBlocks(basicBlocks []*BasicBlock) {
 	}
 }
 
-//TODO: Check after all basic-block types we have declared.
+// GetBasicBlockTypeFromStmt returns the basic-block type of the first statement in stmtList
+// ending or starting a basic-block, and the statement, or UNKNOWN and nil if there is none.
 func GetBasicBlockTypeFromStmt(stmtList []ast.Stmt) (BasicBlockType, ast.Stmt) {
 	for _, stmt := range stmtList {
 		switch stmt.(type) {
@@ -222,127 +670,771 @@ func GetBasicBlockTypeFromStmt(stmtList []ast.Stmt) (BasicBlockType, ast.Stmt) {
 			return CASE_CLAUSE, stmt
 		case *ast.SwitchStmt:
 			return SWITCH_STATEMENT, stmt
+		case *ast.ForStmt:
+			return FOR_STATEMENT, stmt
+		case *ast.RangeStmt:
+			return RANGE_STATEMENT, stmt
+		case *ast.IfStmt:
+			return IF_CONDITION, stmt
+		case *ast.SelectStmt:
+			return SELECT_STATEMENT, stmt
+		case *ast.BranchStmt:
+			if basicBlockType := branchBlockType(stmt.(*ast.BranchStmt).Tok); basicBlockType != UNKNOWN {
+				return basicBlockType, stmt
+			}
 		}
 	}
 	return UNKNOWN, nil
 }
 
-func (v *visitor) Visit(node ast.Node) (w ast.Visitor) {
+// branchBlockType returns the basic-block type of the branch statement tok, or UNKNOWN
+// for branch statements without a basic-block of their own.
+func branchBlockType(tok token.Token) BasicBlockType {
+	switch tok {
+	case token.GOTO:
+		return GOTO_STATEMENT
+	case token.BREAK:
+		return BREAK_STMT
+	case token.CONTINUE:
+		return CONTINUE_STMT
+	}
+	return UNKNOWN
+}
+
+// hasDefaultClause reports whether the body of a switch statement has a default clause.
+func hasDefaultClause(body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		if caseClause, ok := stmt.(*ast.CaseClause); ok && caseClause.List == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// caseLabel returns the label of the edge from a switch statement to caseClause, the case
+// expressions separated by commas, or DEFAULT_EDGE for the default clause.
+func caseLabel(caseClause *ast.CaseClause) string {
+	if caseClause.List == nil {
+		return DEFAULT_EDGE
+	}
+	expressions := make([]string, len(caseClause.List))
+	for index, expr := range caseClause.List {
+		expressions[index] = types.ExprString(expr)
+	}
+	return strings.Join(expressions, ", ")
+}
+
+// endsInFallthrough reports whether the case clause body stmtList ends with a fallthrough statement.
+func endsInFallthrough(stmtList []ast.Stmt) bool {
+	if len(stmtList) == 0 {
+		return false
+	}
+	branchStmt, ok := stmtList[len(stmtList)-1].(*ast.BranchStmt)
+	return ok && branchStmt.Tok == token.FALLTHROUGH
+}
+
+// isJump reports whether basic-blocks of blockType end in a jump, and never continue
+// with the next basic-block in sequence.
+func isJump(blockType BasicBlockType) bool {
+	return blockType == RETURN_STMT || blockType == GOTO_STATEMENT || blockType == BREAK_STMT || blockType == CONTINUE_STMT
+}
+
+// isLoop reports whether blockType is the header of a for or range loop.
+func isLoop(blockType BasicBlockType) bool {
+	return blockType == FOR_STATEMENT || blockType == RANGE_STATEMENT
+}
+
+// callsRecover reports whether node contains a call to the built-in recover(),
+// not counting calls inside function literals.
+func callsRecover(node ast.Node) bool {
+	found := false
 	if node != nil {
-		switch t := node.(type) {
+		ast.Inspect(node, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.CallExpr:
+				if ident, ok := t.Fun.(*ast.Ident); ok && ident.Name == "recover" && len(t.Args) == 0 {
+					found = true
+				}
+			}
+			return !found
+		})
+	}
+	return found
+}
 
-		case *ast.FuncDecl:
-			funcDeclBlock := v.AddBasicBlock(FUNCTION_ENTRY, t.Pos())
-			funcDeclBlock.FunctionName = t.Name.Name
+// recoverTested reports whether s assigns the result of recover() to a variable tested by the
+// condition of the if statement following it, as r := recover(); if r != nil {...}. The if
+// statement decides whether the panic is recovered, as when recover() is called in its init.
+func recoverTested(s ast.Stmt, following []ast.Stmt) bool {
+	assignStmt, ok := s.(*ast.AssignStmt)
+	if !ok || len(assignStmt.Lhs) != 1 || len(assignStmt.Rhs) != 1 || len(following) == 0 {
+		return false
+	}
+	variable, ok := assignStmt.Lhs[0].(*ast.Ident)
+	if !ok || variable.Name == "_" || !callsRecover(assignStmt.Rhs[0]) {
+		return false
+	}
+	ifStmt, ok := following[0].(*ast.IfStmt)
+	if !ok {
+		return false
+	}
 
-			for _, s := range t.Body.List {
-				if _, ok := s.(*ast.ReturnStmt); ok {
-					v.returnBlock = v.AddBasicBlock(RETURN_STMT, s.End())
+	tested := false
+	ast.Inspect(ifStmt.Cond, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok && ident.Name == variable.Name {
+			tested = true
+		}
+		return !tested
+	})
+	return tested
+}
+
+// isPanicStmt reports whether s is a call to the built-in panic, as panic(err).
+func isPanicStmt(s ast.Stmt) bool {
+	if exprStmt, ok := s.(*ast.ExprStmt); ok {
+		call, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+		if ok {
+			ident, ok := call.Fun.(*ast.Ident)
+			return ok && ident.Name == "panic"
+		}
+	}
+	return false
+}
+
+// containsLoopBreak reports whether the statements contains an unlabeled break
+// leaving the enclosing loop, switch or select, ignoring breaks belonging to nested
+// loops, switches, selects and function literals.
+func containsLoopBreak(stmtList []ast.Stmt) bool {
+	found := false
+	for _, stmt := range stmtList {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+				return false
+			case *ast.BranchStmt:
+				if t.Tok == token.BREAK && t.Label == nil {
+					found = true
 				}
 			}
+			return !found
+		})
+	}
+	return found
+}
+
+// endsInBreak reports whether the last of the statements is an unlabeled break.
+func endsInBreak(stmtList []ast.Stmt) bool {
+	if len(stmtList) == 0 {
+		return false
+	}
+	branchStmt, ok := stmtList[len(stmtList)-1].(*ast.BranchStmt)
+	return ok && branchStmt.Tok == token.BREAK && branchStmt.Label == nil
+}
+
+// visitFunction adds the FUNCTION_ENTRY block of function name starting at position,
+// and the basic-blocks of all statements in body.
+func (v *visitor) visitFunction(name string, position token.Pos, body *ast.BlockStmt) {
+	//A body on a single line, as func abs(x int) int { if x < 0 { return -x }; return x }, shares
+	//the line with its statements and closing brace. Its blocks are keyed by statement, following
+	//the entry block.
+	if v.line(body.Lbrace) == v.line(body.Rbrace) {
+		v.statementStarts = append(statementStarts(body.List), body.Rbrace)
+	}
+	v.visitFunctionBody(name, position, body.List, body.End(), v.key(body.End()))
+	v.statementStarts = nil
+}
 
-			if v.returnBlock == nil {
-				v.returnBlock = v.AddBasicBlock(RETURN_STMT, t.End())
+// enterFunction adds the FUNCTION_ENTRY block of function name starting at position, and starts
+// the visit of its body.
+func (v *visitor) enterFunction(name string, position token.Pos) {
+	funcDeclBlock := v.addKeyedBasicBlock(FUNCTION_ENTRY, position, v.line(position)<<lineKeyShift)
+	funcDeclBlock.FunctionName = name
+	v.functionName = name
+	v.funcLitCount = 0
+	v.deferBlocks = nil
+	v.labelBlocks = map[string]*BasicBlock{}
+	v.gotos = nil
+	v.branchTargets = nil
+	v.switchBlocks = nil
+}
+
+// visitFunctionBody adds the FUNCTION_ENTRY block of function name starting at position, and the
+// basic-blocks of the statements in list, ending at end. Without return statement in list, the
+// function returns in a RETURN_STMT block at end, keyed by endKey.
+func (v *visitor) visitFunctionBody(name string, position token.Pos, list []ast.Stmt, end token.Pos, endKey int) {
+	v.enterFunction(name, position)
+	for _, s := range list {
+		if _, ok := s.(*ast.ReturnStmt); ok {
+			v.returnBlock = v.AddBasicBlock(RETURN_STMT, s.End())
+		}
+	}
+
+	if v.returnBlock == nil {
+		v.returnBlock = v.addKeyedBasicBlock(RETURN_STMT, end, endKey)
+		v.returnBlock.implicit = true
+	}
+
+	//Visit all statements in body.
+	v.visitStmtList(list)
+	v.linkDeferBlocks(v.line(position)<<lineKeyShift, endKey)
+	v.linkGotos()
+
+	v.returnBlock = nil
+}
+
+// linkDeferBlocks links every return block keyed between first and last to the last deferred
+// call before it, as deferred calls run when the function returns. Later deferred calls are
+// not yet registered when returning.
+func (v *visitor) linkDeferBlocks(first, last int) {
+	for key, bb := range v.basicBlocks {
+		if bb.Type != RETURN_STMT || key < first || key > last {
+			continue
+		}
+		for index := len(v.deferBlocks) - 1; index >= 0; index-- {
+			if v.deferBlocks[index].key < key {
+				bb.AddSuccessorBlock(v.deferBlocks[index])
+				break
 			}
+		}
+	}
+}
+
+// linkGotos links every goto block to the block starting the statement with its label.
+func (v *visitor) linkGotos() {
+	for _, gotoStmt := range v.gotos {
+		if labelBlock, ok := v.labelBlocks[gotoStmt.label]; ok {
+			gotoStmt.block.AddSuccessorBlock(labelBlock)
+		}
+	}
+}
+
+// pushBranchTarget makes the statement being visited the innermost target of break and
+// continue statements, until popBranchTarget is called.
+func (v *visitor) pushBranchTarget(continueBlock, breakBlock *BasicBlock) {
+	v.branchTargets = append(v.branchTargets, &branchTarget{label: v.label, continueBlock: continueBlock, breakBlock: breakBlock})
+	v.label = ""
+}
+
+// popBranchTarget removes the innermost target of break and continue statements.
+func (v *visitor) popBranchTarget() {
+	v.branchTargets = v.branchTargets[:len(v.branchTargets)-1]
+}
 
-			//Visit all statements in body.
-			for _, s := range t.Body.List {
-				v.Visit(s)
+// visitSwitchBody visits the clauses in body of the switch or select statement with
+// basic-block switchBlock, linked from switchBlock while it is the innermost switch. The
+// clauses are nested one level deeper than the statement.
+func (v *visitor) visitSwitchBody(switchBlock *BasicBlock, body *ast.BlockStmt) {
+	v.switchBlocks = append(v.switchBlocks, switchBlock)
+	v.pushBranchTarget(nil, v.returnBlock)
+	v.depth++
+	for _, s := range body.List {
+		v.Visit(s)
+	}
+	v.depth--
+	v.popBranchTarget()
+	v.switchBlocks = v.switchBlocks[:len(v.switchBlocks)-1]
+}
+
+// switchBlock returns the basic-block of the innermost switch or select statement being
+// visited, nil if none.
+func (v *visitor) switchBlock() *BasicBlock {
+	if len(v.switchBlocks) == 0 {
+		return nil
+	}
+	return v.switchBlocks[len(v.switchBlocks)-1]
+}
+
+// loopBlock returns the header of the innermost enclosing loop, or nil outside loops.
+func (v *visitor) loopBlock() *BasicBlock {
+	for index := len(v.branchTargets) - 1; index >= 0; index-- {
+		if v.branchTargets[index].continueBlock != nil {
+			return v.branchTargets[index].continueBlock
+		}
+	}
+	return nil
+}
+
+// branchTargetBlock returns the block a break or continue statement with label jumps to,
+// the block following the innermost enclosing loop, switch or select for break, and the
+// header of the innermost enclosing loop for continue.
+func (v *visitor) branchTargetBlock(tok token.Token, label *ast.Ident) *BasicBlock {
+	for index := len(v.branchTargets) - 1; index >= 0; index-- {
+		target := v.branchTargets[index]
+		if label != nil && label.Name != target.label {
+			continue
+		}
+		if tok == token.BREAK {
+			return target.breakBlock
+		}
+		if target.continueBlock != nil {
+			return target.continueBlock
+		}
+	}
+	return nil
+}
+
+// visitStmtList visits the statements in list. Bare function calls get a CALL_EXPRESSION
+// block, and in STATEMENT_BLOCKS mode every simple statement gets a basic-block. Control
+// structures continue in the first basic-block of the statements following them, or in the
+// return block if none of the following statements has a basic-block.
+func (v *visitor) visitStmtList(list []ast.Stmt) {
+	for index, s := range list {
+		v.label = ""
+		for {
+			labeledStmt, ok := s.(*ast.LabeledStmt)
+			if !ok {
+				break
 			}
+			//A statement starting on the line of its label, such as a loop, shares block with the label.
+			v.labelBlocks[labeledStmt.Label.Name] = v.AddBasicBlock(LABELED_STMT, labeledStmt.Pos())
+			v.label = labeledStmt.Label.Name
+			s = labeledStmt.Stmt
+		}
 
-			v.returnBlock = nil
-			return nil
+		switch s.(type) {
+		case *ast.AssignStmt, *ast.DeclStmt, *ast.ExprStmt, *ast.IncDecStmt, *ast.SendStmt:
+			if v.statementBlocks || isCallStmt(s) {
+				v.lastBlock = v.statementBlock(s)
+			}
+			v.recoverTested = recoverTested(s, list[index+1:])
+			v.Visit(s)
+			v.recoverTested = false
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.BlockStmt:
+			v.visitCompoundStmt(s, list[index+1:])
+		default:
+			v.Visit(s)
+		}
+	}
+}
 
-		case *ast.ReturnStmt:
-			v.returnBlock = v.AddBasicBlock(RETURN_STMT, t.Pos())
-			if v.switchBlock != nil {
-				v.switchBlock.AddSuccessorBlock(v.returnBlock)
+// visitBody visits the statements in list as visitStmtList, nested one level deeper than the
+// statement being visited.
+func (v *visitor) visitBody(list []ast.Stmt) {
+	v.depth++
+	v.visitStmtList(list)
+	v.depth--
+}
+
+// visitLoopBody visits body of the for or range loop with header loopBlock, linking the end of
+// the body back to the header. The loop is left from its header.
+func (v *visitor) visitLoopBody(loopBlock *BasicBlock, body *ast.BlockStmt) {
+	v.pushBranchTarget(loopBlock, v.returnBlock)
+	tmpReturnBlock := v.returnBlock
+	v.returnBlock = loopBlock
+	v.visitBody(body.List)
+	v.returnBlock = tmpReturnBlock
+	v.popBranchTarget()
+
+	//Statement blocks in the body must not fall through to the block after the loop.
+	if v.lastBlock == loopBlock || v.lastBlock.Type == STATEMENT || v.lastBlock.Type == CALL_EXPRESSION {
+		v.addBodyBlock(FOR_BODY, body.End())
+	}
+
+	//A loop ending the body is left through its exit edge, a loop without exit is never left.
+	if !isJump(v.lastBlock.Type) && !isLoop(v.lastBlock.Type) {
+		v.lastBlock.AddSuccessorBlock(loopBlock)
+	}
+
+	v.lastBlock = loopBlock
+}
+
+// addBodyBlock adds a basic-block as AddBasicBlock, ending a body nested one level deeper than
+// the statement being visited.
+func (v *visitor) addBodyBlock(blockType BasicBlockType, position token.Pos) *BasicBlock {
+	v.depth++
+	defer func() { v.depth-- }()
+	return v.AddBasicBlock(blockType, position)
+}
+
+// visitCompoundStmt visits the compound statement s, continuing with the first of the
+// statements following it having a basic-block.
+func (v *visitor) visitCompoundStmt(s ast.Stmt, following []ast.Stmt) {
+	tmpReturnBlock := v.returnBlock
+	for _, next := range flattenBlocks(following) {
+		if _, ok := next.(*ast.DeferStmt); ok {
+			continue //Deferred calls run on return, control continues after them.
+		}
+		if isEmptyStmt(next) {
+			continue
+		}
+		if v.statementBlocks || hasBasicBlock(next) {
+			v.returnBlock = v.statementBlock(next)
+			break
+		}
+	}
+	v.Visit(s)
+	v.returnBlock = tmpReturnBlock
+}
+
+// visitClauseBody visits the statements in the body of a case or comm clause. Unlike
+// visitStmtList, statements share the basic-block of the clause, but switch and select
+// statements continue with the statements following them in the clause.
+func (v *visitor) visitClauseBody(body []ast.Stmt) {
+	for index, s := range body {
+		switch s.(type) {
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			v.visitCompoundStmt(s, body[index+1:])
+		default:
+			v.Visit(s)
+		}
+	}
+}
+
+// visitIf adds the basic-blocks of the if statement, and returns its IF_CONDITION block. Without
+// else branch, the false branch continues in the block following the if statement, and an if body
+// without basic-blocks of its own gets an IF_BODY block on its closing brace, keeping the true and
+// false branches apart. An else if is visited as an if statement of its own, entered by the false
+// branch, and the body before it ends in an IF_BODY block on its last line instead of falling
+// through to the condition of the else if. The blocks of an else body are not visited.
+func (v *visitor) visitIf(t *ast.IfStmt) *BasicBlock {
+	ifBlock := v.AddBasicBlock(IF_CONDITION, t.Pos()) //A recover() in init or condition is decided by the if statement.
+	continueBlock := v.returnBlock                    //Block following the if statement, kept from returns in the body.
+
+	switch elseStmt := t.Else.(type) {
+	case nil:
+		v.visitBody(t.Body.List)
+		v.returnBlock = continueBlock
+		if continueBlock == nil {
+			break
+		}
+		ifBlock.addLabeledSuccessorBlock(FALSE_EDGE, continueBlock)
+		if _, ok := v.basicBlocks[v.key(t.Body.Rbrace)]; v.lastBlock == ifBlock && !ok {
+			v.addBodyBlock(IF_BODY, t.Body.Rbrace).AddSuccessorBlock(continueBlock)
+		}
+
+	case *ast.IfStmt:
+		v.visitBody(t.Body.List)
+		v.returnBlock = continueBlock
+		fallsThrough := v.lastBlock == ifBlock || v.lastBlock.Type == CALL_EXPRESSION || v.lastBlock.Type == STATEMENT ||
+			v.lastBlock.Type == GO_STATEMENT || v.lastBlock.Type == RECOVER_CALL
+		if last := len(t.Body.List) - 1; last >= 0 && fallsThrough && v.key(t.Body.List[last].End()) != v.key(t.Pos()) {
+			bodyBlock := v.addBodyBlock(IF_BODY, t.Body.List[last].End())
+			if continueBlock != nil {
+				bodyBlock.AddSuccessorBlock(continueBlock)
 			}
+		}
+		ifBlock.addLabeledSuccessorBlock(FALSE_EDGE, v.visitIf(elseStmt))
 
-		case *ast.GoStmt:
-			v.AddBasicBlock(GO_STATEMENT, t.Pos())
+	default:
+		elseConditionBlock := v.AddBasicBlock(ELSE_CONDITION, t.Else.Pos())
+		elseBodyBlock := v.addBodyBlock(ELSE_BODY, t.Else.End())
 
-		case *ast.IfStmt:
-			ifBlock := v.AddBasicBlock(IF_CONDITION, t.Pos())
-			elseConditionBlock := v.AddBasicBlock(ELSE_CONDITION, t.Else.Pos())
-			elseBodyBlock := v.AddBasicBlock(ELSE_BODY, t.Else.End())
+		ifBlock.addLabeledSuccessorBlock(FALSE_EDGE, elseBodyBlock)
 
-			ifBlock.AddSuccessorBlock(elseBodyBlock)
+		//The else body is not visited, its block carries the edge of a break in it.
+		elseBody := elseStmt.(*ast.BlockStmt).List
+		v.visitBody(t.Body.List)
+		if !isJump(v.lastBlock.Type) {
+			v.lastBlock = elseBodyBlock //Blocks of the if statement end with the else body.
+			if endsInBreak(elseBody) {
+				v.lastBlock = elseConditionBlock //Only the if body continues after the statement.
+			}
+		}
 
-			for _, stmt := range t.Body.List {
-				v.Visit(stmt)
+		v.returnBlock = continueBlock
+		if containsLoopBreak(elseBody) {
+			if breakBlock := v.branchTargetBlock(token.BREAK, nil); breakBlock != nil {
+				elseBodyBlock.AddSuccessorBlock(breakBlock)
 			}
+		}
+		if continueBlock != nil {
+			elseConditionBlock.AddSuccessorBlock(continueBlock)
+			if !endsInBreak(elseBody) {
+				elseBodyBlock.AddSuccessorBlock(continueBlock)
+			}
+		}
+	}
+	return ifBlock
+}
 
-			if v.returnBlock != nil {
-				elseConditionBlock.AddSuccessorBlock(v.returnBlock)
-				elseBodyBlock.AddSuccessorBlock(v.returnBlock)
+// line returns the line of position in the source file.
+func (v *visitor) line(position token.Pos) int {
+	return v.sourceFileSet.File(position).Line(position)
+}
+
+// key returns the key of the basic-block at position, its line shifted by lineKeyShift, giving the
+// blocks of a line a single key. Statements in statementStarts start lines of their own, the key then
+// adds the number of statements starting on the line up to position.
+func (v *visitor) key(position token.Pos) int {
+	line := v.line(position)
+	statement := 0
+	for index := sort.Search(len(v.statementStarts), func(i int) bool { return v.statementStarts[i] > position }) - 1; index >= 0 &&
+		v.line(v.statementStarts[index]) == line; index-- {
+		statement++
+	}
+	return line<<lineKeyShift | statement
+}
+
+// statementStarts returns the positions of stmts and of the statements and case and comm clauses
+// nested in them, and of the closing braces of their blocks, in source order. Function literals are
+// left out, they are analysed as separate functions.
+func statementStarts(stmts []ast.Stmt) []token.Pos {
+	starts := []token.Pos{}
+	for _, stmt := range stmts {
+		starts = append(starts, stmt.Pos())
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.BlockStmt:
+				for _, s := range t.List {
+					starts = append(starts, s.Pos())
+				}
+				starts = append(starts, t.Rbrace)
+			case *ast.CaseClause:
+				for _, s := range t.Body {
+					starts = append(starts, s.Pos())
+				}
+			case *ast.CommClause:
+				for _, s := range t.Body {
+					starts = append(starts, s.Pos())
+				}
 			}
+			return true
+		})
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	return starts
+}
 
-		case *ast.ForStmt:
-			v.forBlock = v.AddBasicBlock(FOR_STATEMENT, t.Pos())
-			if v.returnBlock != nil {
-				v.forBlock.AddSuccessorBlock(v.returnBlock)
+// statementBlock returns the basic-block on the line of s, adding a CALL_EXPRESSION block for
+// bare calls or a STATEMENT block if the line has none. Control structures on the line update
+// the block later.
+func (v *visitor) statementBlock(s ast.Stmt) *BasicBlock {
+	position := s.Pos()
+	switch t := s.(type) {
+	case *ast.ForStmt:
+		position = t.Body.Lbrace //Loop block ends with the loop header.
+	case *ast.RangeStmt:
+		position = t.Body.Lbrace
+	}
+	key := v.key(position)
+	if bb, ok := v.basicBlocks[key]; ok {
+		return bb
+	}
+	blockType := STATEMENT
+	if isCallStmt(s) {
+		blockType = CALL_EXPRESSION
+	}
+	sourcePosition := v.sourceFileSet.File(position).Position(position)
+	v.basicBlocks[key] = NewBasicBlock(-1, blockType, sourcePosition.Line)
+	v.basicBlocks[key].key = key
+	v.basicBlocks[key].StartColumn, v.basicBlocks[key].EndColumn = sourcePosition.Column, sourcePosition.Column
+	v.basicBlocks[key].Depth = v.depth
+	return v.basicBlocks[key]
+}
+
+// setStart sets the first line and column of basicBlock to position, for blocks spanning several lines.
+func (v *visitor) setStart(basicBlock *BasicBlock, position token.Pos) {
+	start := v.sourceFileSet.File(position).Position(position)
+	basicBlock.StartLine, basicBlock.StartColumn = start.Line, start.Column
+}
+
+// hasBasicBlock reports whether the visitor adds a basic-block on the line of s.
+func hasBasicBlock(s ast.Stmt) bool {
+	switch t := s.(type) {
+	case *ast.ReturnStmt, *ast.GoStmt, *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt,
+		*ast.SelectStmt, *ast.LabeledStmt:
+		return true
+	case *ast.BranchStmt:
+		return branchBlockType(t.Tok) != UNKNOWN
+	case *ast.AssignStmt, *ast.DeclStmt, *ast.ExprStmt:
+		return isCallStmt(s) || callsRecover(t)
+	}
+	return false
+}
+
+// isEmptyStmt reports whether s is a stray semicolon or an empty block, doing nothing.
+func isEmptyStmt(s ast.Stmt) bool {
+	switch t := s.(type) {
+	case *ast.EmptyStmt:
+		return true
+	case *ast.BlockStmt:
+		return len(t.List) == 0
+	}
+	return false
+}
+
+// flattenBlocks returns the statements in list, with the statements of bare blocks in
+// place of the blocks.
+func flattenBlocks(list []ast.Stmt) []ast.Stmt {
+	flattened := []ast.Stmt{}
+	for _, s := range list {
+		if block, ok := s.(*ast.BlockStmt); ok {
+			flattened = append(flattened, flattenBlocks(block.List)...)
+		} else {
+			flattened = append(flattened, s)
+		}
+	}
+	return flattened
+}
+
+// isCallStmt reports whether s is an expression statement calling a function, as doWork().
+func isCallStmt(s ast.Stmt) bool {
+	if exprStmt, ok := s.(*ast.ExprStmt); ok {
+		_, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+		return ok
+	}
+	return false
+}
+
+// addFuncLits records the function literals in node, such as closures assigned to
+// variables, called in go statements, given as call arguments, as in register(func() {...}),
+// or as element values of composite literals, as in struct{ fn func() }{ fn: func() {...} }.
+func (v *visitor) addFuncLits(node ast.Node) {
+	if node == nil {
+		return
+	}
+	ast.Inspect(node, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.FuncLit); ok {
+			v.addFuncLit(lit)
+			return false //Body is analysed as a separate function.
+		}
+		return true
+	})
+}
+
+// addFuncLit records the function literal lit, to be analysed as a separate function.
+func (v *visitor) addFuncLit(lit *ast.FuncLit) {
+	v.funcLitCount++
+	name := fmt.Sprintf("%s$func%d", v.functionName, v.funcLitCount)
+	v.funcLits = append(v.funcLits, &funcLit{name: name, node: lit})
+}
+
+func (v *visitor) Visit(node ast.Node) (w ast.Visitor) {
+	if node != nil {
+		switch t := node.(type) {
+
+		case *ast.FuncDecl:
+			//A function declared without body, as with //go:linkname, is implemented elsewhere.
+			if t.Body != nil {
+				v.visitFunction(QualifiedFuncName(t), t.Pos(), t.Body)
 			}
+			return nil
 
-			tmpReturnBlock := v.returnBlock
-			v.returnBlock = v.forBlock
-			for _, s := range t.Body.List {
-				v.Visit(s)
+		case *ast.AssignStmt, *ast.DeclStmt, *ast.ExprStmt:
+			v.addFuncLits(t)
+			if callsRecover(t) && !v.recoverTested {
+				v.AddBasicBlock(RECOVER_CALL, t.Pos()).recovers = true
+			}
+			if basicBlock, ok := v.basicBlocks[v.key(t.Pos())]; ok && isPanicStmt(t.(ast.Stmt)) {
+				basicBlock.panics = true
 			}
-			v.returnBlock = tmpReturnBlock
 
-			if v.lastBlock.Type == FOR_STATEMENT {
-				v.AddBasicBlock(FOR_BODY, t.End())
+		case *ast.DeferStmt:
+			v.addFuncLits(t.Call)
+
+			//Deferred calls are left out of the sequential flow, they run in reverse order on return.
+			lastBlock := v.lastBlock
+			deferBlock := v.AddBasicBlock(DEFER_STATEMENT, t.Pos())
+			if len(v.deferBlocks) > 0 {
+				deferBlock.AddSuccessorBlock(v.deferBlocks[len(v.deferBlocks)-1])
 			}
+			v.deferBlocks = append(v.deferBlocks, deferBlock)
+			v.lastBlock = lastBlock
+
+		case *ast.ReturnStmt:
+			v.addFuncLits(t)
+			v.returnBlock = v.AddBasicBlock(RETURN_STMT, t.Pos())
 
-			if v.lastBlock.Type != RETURN_STMT {
-				v.lastBlock.AddSuccessorBlock(v.forBlock)
+		case *ast.BranchStmt:
+			switch t.Tok {
+			case token.GOTO:
+				v.gotos = append(v.gotos, &gotoStmt{block: v.AddBasicBlock(GOTO_STATEMENT, t.Pos()), label: t.Label.Name})
+			case token.BREAK, token.CONTINUE:
+				branchBlock := v.AddBasicBlock(branchBlockType(t.Tok), t.Pos())
+				if target := v.branchTargetBlock(t.Tok, t.Label); target != nil {
+					branchBlock.AddSuccessorBlock(target)
+				}
 			}
 
-			v.forBlock = nil
+		case *ast.BlockStmt:
+			//A bare block only scopes its statements, control flows through it.
+			v.visitStmtList(t.List)
 			return nil
 
-		case *ast.SwitchStmt:
-			v.switchBlock = v.AddBasicBlock(SWITCH_STATEMENT, t.Pos())
-			if v.forBlock != nil {
-				v.forBlock.AddSuccessorBlock(v.switchBlock)
-				v.switchBlock.AddSuccessorBlock(v.forBlock)
+		case *ast.GoStmt:
+			v.addFuncLits(t.Call)
+			v.AddBasicBlock(GO_STATEMENT, t.Pos())
+
+		case *ast.IfStmt:
+			v.visitIf(t)
+			return nil
+
+		case *ast.ForStmt:
+			//The loop header spans init, condition and post statement, ending where the body starts.
+			forBlock := v.AddBasicBlock(FOR_STATEMENT, t.Body.Lbrace)
+			v.setStart(forBlock, t.Pos())
+			forBlock.recovers = callsRecover(t.Init) || callsRecover(t.Cond) || callsRecover(t.Post)
+			v.addFuncLits(t.Init)
+			v.addFuncLits(t.Post)
+			//A loop without condition (for {}) is only left through break, its break blocks carry the exit edge.
+			if v.returnBlock != nil && t.Cond != nil {
+				forBlock.addLabeledSuccessorBlock(EXIT_EDGE, v.returnBlock)
 			}
 
+			v.visitLoopBody(forBlock, t.Body)
+			return nil
+
+		case *ast.RangeStmt:
+			//The loop header holds the range expression, and is left when the range is exhausted.
+			rangeBlock := v.AddBasicBlock(RANGE_STATEMENT, t.Body.Lbrace)
+			v.setStart(rangeBlock, t.Pos())
+			rangeBlock.recovers = callsRecover(t.X)
+			v.addFuncLits(t.X)
 			if v.returnBlock != nil {
-				v.switchBlock.AddSuccessorBlock(v.returnBlock)
+				rangeBlock.addLabeledSuccessorBlock(EXIT_EDGE, v.returnBlock)
+			}
+
+			v.visitLoopBody(rangeBlock, t.Body)
+			return nil
+
+		case *ast.SwitchStmt:
+			switchBlock := v.AddBasicBlock(SWITCH_STATEMENT, t.Pos())
+			if forBlock := v.loopBlock(); forBlock != nil {
+				forBlock.AddSuccessorBlock(switchBlock)
+				switchBlock.AddSuccessorBlock(forBlock)
 			}
 
-			for _, s := range t.Body.List {
-				v.Visit(s)
+			//Without a default clause no case may match, continuing after the statement.
+			if v.returnBlock != nil && !hasDefaultClause(t.Body) {
+				switchBlock.AddSuccessorBlock(v.returnBlock)
 			}
+
+			v.visitSwitchBody(switchBlock, t.Body)
 			return nil
 
 		case *ast.TypeSwitchStmt:
-			v.switchBlock = v.AddBasicBlock(SWITCH_STATEMENT, t.Pos())
-			if v.forBlock != nil {
-				v.forBlock.AddSuccessorBlock(v.switchBlock)
-				v.switchBlock.AddSuccessorBlock(v.forBlock)
+			switchBlock := v.AddBasicBlock(SWITCH_STATEMENT, t.Pos())
+			if forBlock := v.loopBlock(); forBlock != nil {
+				forBlock.AddSuccessorBlock(switchBlock)
+				switchBlock.AddSuccessorBlock(forBlock)
 			}
 
-			for _, s := range t.Body.List {
-				v.Visit(s)
+			//Without a default clause no case may match, continuing after the statement.
+			if v.returnBlock != nil && !hasDefaultClause(t.Body) {
+				switchBlock.AddSuccessorBlock(v.returnBlock)
 			}
+
+			v.visitSwitchBody(switchBlock, t.Body)
 			return nil
 
 		case *ast.SelectStmt:
-			v.switchBlock = v.AddBasicBlock(SELECT_STATEMENT, t.Pos())
-			if v.forBlock != nil {
-				v.forBlock.AddSuccessorBlock(v.switchBlock)
-				v.switchBlock.AddSuccessorBlock(v.forBlock)
+			//A select statement runs one of its clauses, blocking until one can run if there is no default
+			//clause, and forever if there are no clauses. Unlike a switch statement it is never skipped.
+			selectBlock := v.AddBasicBlock(SELECT_STATEMENT, t.Pos())
+			if forBlock := v.loopBlock(); forBlock != nil {
+				forBlock.AddSuccessorBlock(selectBlock)
 			}
 
-			for _, s := range t.Body.List {
-				v.Visit(s)
-			}
+			v.visitSwitchBody(selectBlock, t.Body)
 			return nil
 
 		case *ast.CaseClause:
+			//A case ending in a return, or holding a switch, shares basic-block with that statement. The block
+			//must end on the line of the statement, also when other statements come first in the case.
 			var caseClause *BasicBlock
 			if basicBlockType, s := GetBasicBlockTypeFromStmt(t.Body); basicBlockType != UNKNOWN {
 				caseClause = v.AddBasicBlock(basicBlockType, s.Pos())
@@ -350,39 +1442,37 @@ func (v *visitor) Visit(node ast.Node) (w ast.Visitor) {
 				caseClause = v.AddBasicBlock(CASE_CLAUSE, t.End())
 			}
 
-			if v.forBlock != nil {
-				caseClause.AddSuccessorBlock(v.forBlock)
+			//A case falling through continues in the next case clause only.
+			fallsThrough := endsInFallthrough(t.Body)
+			if v.fallthroughBlock != nil {
+				v.fallthroughBlock.AddSuccessorBlock(caseClause)
+				v.fallthroughBlock = nil
 			}
 
-			if v.switchBlock != nil {
-				v.switchBlock.AddSuccessorBlock(caseClause)
+			//A case ending in a jump, as a labeled break, only continues at the target of the jump.
+			if forBlock := v.loopBlock(); forBlock != nil && !fallsThrough && !isJump(caseClause.Type) {
+				caseClause.AddSuccessorBlock(forBlock)
 			}
 
-			if v.returnBlock != nil {
+			if switchBlock := v.switchBlock(); switchBlock != nil {
+				switchBlock.addLabeledSuccessorBlock(caseLabel(t), caseClause)
+			}
+
+			if v.returnBlock != nil && !fallsThrough && !isJump(caseClause.Type) {
 				caseClause.AddSuccessorBlock(v.returnBlock)
 			}
 
-			tmpSwitchBlock := v.switchBlock
 			tmpReturnBLock := v.returnBlock
-			for _, s := range t.Body {
-				v.Visit(s)
-			}
-			v.switchBlock = tmpSwitchBlock
+			v.visitClauseBody(t.Body)
 			v.returnBlock = tmpReturnBLock
+			v.setStart(caseClause, t.Pos()) //The clause block spans the whole clause.
 
 			//TODO: Special case.
 			//TODO: Type is always CASE_CLAUSE type
-			if v.returnBlock != nil && caseClause.Type != RETURN_STMT && caseClause.Type != SWITCH_STATEMENT {
-				//TODO: This must be refactored more beautiful
-				containsForStatement := false
-				for _, b := range caseClause.GetSuccessorBlocks() {
-					if b.Type == FOR_STATEMENT {
-						containsForStatement = true
-					}
-				}
-				if !containsForStatement {
-					caseClause.AddSuccessorBlock(v.returnBlock)
-				}
+			if fallsThrough {
+				v.fallthroughBlock = caseClause
+			} else if v.returnBlock != nil && !isJump(caseClause.Type) && caseClause.Type != SWITCH_STATEMENT {
+				caseClause.AddSuccessorBlock(v.returnBlock)
 			}
 
 		case *ast.CommClause:
@@ -393,40 +1483,28 @@ func (v *visitor) Visit(node ast.Node) (w ast.Visitor) {
 				caseClause = v.AddBasicBlock(COMM_CLAUSE, t.End())
 			}
 
-			if v.forBlock != nil {
-				caseClause.AddSuccessorBlock(v.forBlock)
+			//A clause ending in a jump, as a labeled break, only continues at the target of the jump.
+			if forBlock := v.loopBlock(); forBlock != nil && !isJump(caseClause.Type) {
+				caseClause.AddSuccessorBlock(forBlock)
 			}
 
-			if v.switchBlock != nil {
-				v.switchBlock.AddSuccessorBlock(caseClause)
+			if switchBlock := v.switchBlock(); switchBlock != nil {
+				switchBlock.AddSuccessorBlock(caseClause)
 			}
 
-			if v.returnBlock != nil {
-				//TODO: Should this be here, what about the last check further down, the same with CaseClause
+			if v.returnBlock != nil && !isJump(caseClause.Type) {
 				caseClause.AddSuccessorBlock(v.returnBlock)
 			}
 
-			tmpSwitchBlock := v.switchBlock
 			tmpReturnBLock := v.returnBlock
-			for _, s := range t.Body {
-				v.Visit(s)
-			}
-			v.switchBlock = tmpSwitchBlock
+			v.visitClauseBody(t.Body)
 			v.returnBlock = tmpReturnBLock
+			v.setStart(caseClause, t.Pos()) //The clause block spans the whole clause.
 
 			//TODO: Special case.
 			//TODO: Type is always CASE_CLAUSE type
-			if v.returnBlock != nil && caseClause.Type != RETURN_STMT && caseClause.Type != SWITCH_STATEMENT {
-				//TODO: This must be refactored more beautiful
-				containsForStatement := false
-				for _, b := range caseClause.GetSuccessorBlocks() {
-					if b.Type == FOR_STATEMENT {
-						containsForStatement = true
-					}
-				}
-				if !containsForStatement {
-					caseClause.AddSuccessorBlock(v.returnBlock)
-				}
+			if v.returnBlock != nil && !isJump(caseClause.Type) && caseClause.Type != SWITCH_STATEMENT {
+				caseClause.AddSuccessorBlock(v.returnBlock)
 			}
 
 		}
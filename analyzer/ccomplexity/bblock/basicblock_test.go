@@ -6,6 +6,10 @@ package bblock_test
 import (
 	"fmt"
 	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
@@ -83,11 +87,15 @@ func TestSingleBasicBlock(t *testing.T) {
 	}
 
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
-	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 11)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.RETURN_STMT, 11)
 
 	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
 
-	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1}
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2,
+	}
 
 	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
 		t.Fatal(err)
@@ -124,6 +132,106 @@ func TestIfElseBasicBlock(t *testing.T) {
 	}
 }
 
+func TestBareIfBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_bareif.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.IF_CONDITION, 11)
+	BB2 := bblock.NewBasicBlock(2, bblock.IF_BODY, 13)
+	BB3 := bblock.NewBasicBlock(3, bblock.IF_CONDITION, 15)
+	BB4 := bblock.NewBasicBlock(4, bblock.CALL_EXPRESSION, 16)
+	BB5 := bblock.NewBasicBlock(5, bblock.CALL_EXPRESSION, 18)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 19)
+	BB7 := bblock.NewBasicBlock(7, bblock.FUNCTION_ENTRY, 21)
+	BB8 := bblock.NewBasicBlock(8, bblock.IF_CONDITION, 23)
+	BB9 := bblock.NewBasicBlock(9, bblock.RETURN_STMT, 24)
+	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 26)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3)
+	BB2.AddSuccessorBlock(BB3)
+	BB3.AddSuccessorBlock(BB4, BB5)
+	BB4.AddSuccessorBlock(BB5)
+	BB5.AddSuccessorBlock(BB6)
+	BB7.AddSuccessorBlock(BB8)
+	BB8.AddSuccessorBlock(BB9, BB10)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//The false branch of an if without else continues after the if statement.
+	testCases := []struct {
+		ifBlock, trueBlock, falseBlock int
+	}{
+		{1, 2, 3}, //Body without basic-blocks ends in IF_BODY.
+		{3, 4, 5},
+		{8, 9, 10}, //Body returning.
+	}
+	for _, testCase := range testCases {
+		ifBlock := expectedBasicBlocks[testCase.ifBlock]
+		if ifBlock.TrueSuccessor() != expectedBasicBlocks[testCase.trueBlock] {
+			t.Errorf("True successor of %s should be %s, and not %s!\n", ifBlock,
+				expectedBasicBlocks[testCase.trueBlock], ifBlock.TrueSuccessor())
+		}
+		if ifBlock.FalseSuccessor() != expectedBasicBlocks[testCase.falseBlock] {
+			t.Errorf("False successor of %s should be %s, and not %s!\n", ifBlock,
+				expectedBasicBlocks[testCase.falseBlock], ifBlock.FalseSuccessor())
+		}
+	}
+}
+
+func TestElseIfChainBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_elseifchain.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.IF_CONDITION, 11)
+	BB2 := bblock.NewBasicBlock(2, bblock.IF_BODY, 12)
+	BB3 := bblock.NewBasicBlock(3, bblock.IF_CONDITION, 13)
+	BB4 := bblock.NewBasicBlock(4, bblock.IF_BODY, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.IF_CONDITION, 15)
+	BB6 := bblock.NewBasicBlock(6, bblock.ELSE_CONDITION, 17)
+	BB7 := bblock.NewBasicBlock(7, bblock.ELSE_BODY, 19)
+	BB8 := bblock.NewBasicBlock(8, bblock.CALL_EXPRESSION, 20)
+	BB9 := bblock.NewBasicBlock(9, bblock.RETURN_STMT, 21)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3)
+	BB2.AddSuccessorBlock(BB8)
+	BB3.AddSuccessorBlock(BB4, BB5)
+	BB4.AddSuccessorBlock(BB8)
+	BB5.AddSuccessorBlock(BB6, BB7)
+	BB6.AddSuccessorBlock(BB8)
+	BB7.AddSuccessorBlock(BB8)
+	BB8.AddSuccessorBlock(BB9)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestNestedIfElseBasicBlock(t *testing.T) {
 	srcFile, err := ioutil.ReadFile("./testcode/_nestedifelse.go")
 	if err != nil {
@@ -136,23 +244,27 @@ func TestNestedIfElseBasicBlock(t *testing.T) {
 
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
 	BB1 := bblock.NewBasicBlock(1, bblock.IF_CONDITION, 11)
-	BB2 := bblock.NewBasicBlock(2, bblock.IF_CONDITION, 15)
-	BB3 := bblock.NewBasicBlock(3, bblock.ELSE_CONDITION, 18)
-	BB4 := bblock.NewBasicBlock(4, bblock.ELSE_BODY, 21)
-	BB5 := bblock.NewBasicBlock(5, bblock.ELSE_CONDITION, 22)
-	BB6 := bblock.NewBasicBlock(6, bblock.ELSE_BODY, 25)
-	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 28)
+	BB2 := bblock.NewBasicBlock(2, bblock.CALL_EXPRESSION, 14)
+	BB3 := bblock.NewBasicBlock(3, bblock.IF_CONDITION, 15)
+	BB4 := bblock.NewBasicBlock(4, bblock.CALL_EXPRESSION, 17)
+	BB5 := bblock.NewBasicBlock(5, bblock.ELSE_CONDITION, 18)
+	BB6 := bblock.NewBasicBlock(6, bblock.ELSE_BODY, 21)
+	BB7 := bblock.NewBasicBlock(7, bblock.ELSE_CONDITION, 22)
+	BB8 := bblock.NewBasicBlock(8, bblock.ELSE_BODY, 25)
+	BB9 := bblock.NewBasicBlock(9, bblock.RETURN_STMT, 28)
 
 	BB0.AddSuccessorBlock(BB1)
-	BB1.AddSuccessorBlock(BB2, BB6)
-	BB2.AddSuccessorBlock(BB3, BB4)
-	BB3.AddSuccessorBlock(BB7)
-	BB4.AddSuccessorBlock(BB7)
-	BB5.AddSuccessorBlock(BB7)
-	BB6.AddSuccessorBlock(BB7)
+	BB1.AddSuccessorBlock(BB2, BB8)
+	BB2.AddSuccessorBlock(BB3)
+	BB3.AddSuccessorBlock(BB4, BB6)
+	BB4.AddSuccessorBlock(BB5)
+	BB5.AddSuccessorBlock(BB9)
+	BB6.AddSuccessorBlock(BB9)
+	BB7.AddSuccessorBlock(BB9)
+	BB8.AddSuccessorBlock(BB9)
 
 	correctBasicBlocks := []*bblock.BasicBlock{
-		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7,
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9,
 	}
 
 	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
@@ -173,14 +285,16 @@ func TestLooperBasicBlock(t *testing.T) {
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
 	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 11)
 	BB2 := bblock.NewBasicBlock(2, bblock.FOR_BODY, 14)
-	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 16)
+	BB3 := bblock.NewBasicBlock(3, bblock.CALL_EXPRESSION, 15)
+	BB4 := bblock.NewBasicBlock(4, bblock.RETURN_STMT, 16)
 
 	BB0.AddSuccessorBlock(BB1)
 	BB1.AddSuccessorBlock(BB2, BB3)
 	BB2.AddSuccessorBlock(BB1)
+	BB3.AddSuccessorBlock(BB4)
 
 	correctBasicBlocks := []*bblock.BasicBlock{
-		BB0, BB1, BB2, BB3,
+		BB0, BB1, BB2, BB3, BB4,
 	}
 
 	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
@@ -207,7 +321,7 @@ func TestSimpleSwitchBasicBlock(t *testing.T) {
 	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 23)
 
 	BB0.AddSuccessorBlock(BB1)
-	BB1.AddSuccessorBlock(BB2, BB3, BB4, BB5, BB6)
+	BB1.AddSuccessorBlock(BB2, BB3, BB4, BB5)
 	BB2.AddSuccessorBlock(BB6)
 	BB3.AddSuccessorBlock(BB6)
 	BB4.AddSuccessorBlock(BB6)
@@ -243,7 +357,7 @@ func TestSwitchBasicBlock(t *testing.T) {
 	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 29)
 
 	BB0.AddSuccessorBlock(BB1)
-	BB1.AddSuccessorBlock(BB2, BB3, BB4, BB5, BB6, BB7, BB8)
+	BB1.AddSuccessorBlock(BB2, BB3, BB4, BB5, BB6, BB7)
 	BB2.AddSuccessorBlock(BB8)
 	BB3.AddSuccessorBlock(BB8)
 	BB4.AddSuccessorBlock(BB8)
@@ -259,6 +373,132 @@ func TestSwitchBasicBlock(t *testing.T) {
 	}
 }
 
+func TestSwitchFallthroughBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switchfallthrough.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.SWITCH_STATEMENT, 12)
+	BB2 := bblock.NewBasicBlock(2, bblock.CASE_CLAUSE, 15)
+	BB3 := bblock.NewBasicBlock(3, bblock.CASE_CLAUSE, 18)
+	BB4 := bblock.NewBasicBlock(4, bblock.CASE_CLAUSE, 21)
+	BB5 := bblock.NewBasicBlock(5, bblock.CASE_CLAUSE, 23)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 26)
+	BB7 := bblock.NewBasicBlock(7, bblock.CASE_CLAUSE, 28)
+	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 30)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3, BB4, BB5, BB6, BB7)
+	BB2.AddSuccessorBlock(BB8)
+	BB3.AddSuccessorBlock(BB8)
+	BB4.AddSuccessorBlock(BB5) //Falls through to case 3.
+	BB5.AddSuccessorBlock(BB8)
+	BB7.AddSuccessorBlock(BB8)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	fallthroughBlock := expectedBasicBlocks[4]
+	if successor := fallthroughBlock.LastSuccessor; successor != expectedBasicBlocks[5] {
+		t.Errorf("Case clause nr. 4 should fall through to case clause nr. 5, and not %v!\n", successor)
+	}
+	for _, successor := range fallthroughBlock.GetSuccessorBlocks() {
+		if successor == expectedBasicBlocks[8] {
+			t.Errorf("Case clause nr. 4 falls through, and should not have return block nr. 8 as successor!\n")
+		}
+	}
+}
+
+func TestSwitchCasePositions(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switch.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Case clause blocks span from the case keyword to the end of the last statement in the clause.
+	testCases := []struct {
+		number                                     int
+		startLine, startColumn, endLine, endColumn int
+	}{
+		{2, 14, 2, 15, 19},
+		{3, 16, 2, 18, 21},
+		{4, 19, 2, 20, 19},
+		{5, 21, 2, 22, 19},
+		{6, 23, 2, 25, 3}, //Case ending in return shares block with the return statement.
+		{7, 26, 2, 27, 50},
+	}
+
+	for _, testCase := range testCases {
+		bb := basicBlocks[testCase.number]
+		if bb.StartLine != testCase.startLine || bb.StartColumn != testCase.startColumn ||
+			bb.EndLine != testCase.endLine || bb.EndColumn != testCase.endColumn {
+			t.Errorf("Basic-block nr. %d should span %d:%d-%d:%d, but spans %d:%d-%d:%d!\n", testCase.number,
+				testCase.startLine, testCase.startColumn, testCase.endLine, testCase.endColumn,
+				bb.StartLine, bb.StartColumn, bb.EndLine, bb.EndColumn)
+		}
+	}
+}
+
+func TestFallthroughDefaultBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_fallthroughdefault.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.CALL_EXPRESSION, 10)
+	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 11)
+	BB4 := bblock.NewBasicBlock(4, bblock.FUNCTION_ENTRY, 13)
+	BB5 := bblock.NewBasicBlock(5, bblock.SWITCH_STATEMENT, 14)
+	BB6 := bblock.NewBasicBlock(6, bblock.CASE_CLAUSE, 17)
+	BB7 := bblock.NewBasicBlock(7, bblock.CASE_CLAUSE, 19)
+	BB8 := bblock.NewBasicBlock(8, bblock.SWITCH_STATEMENT, 22)
+	BB9 := bblock.NewBasicBlock(9, bblock.CASE_CLAUSE, 25)
+	BB10 := bblock.NewBasicBlock(10, bblock.CASE_CLAUSE, 27)
+	BB11 := bblock.NewBasicBlock(11, bblock.CASE_CLAUSE, 29)
+	BB12 := bblock.NewBasicBlock(12, bblock.RETURN_STMT, 31)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3)
+	BB4.AddSuccessorBlock(BB5)
+	BB5.AddSuccessorBlock(BB6, BB7)
+	BB6.AddSuccessorBlock(BB7) //Falls through into the default clause ending the switch.
+	BB7.AddSuccessorBlock(BB8)
+	BB8.AddSuccessorBlock(BB9, BB10, BB11)
+	BB9.AddSuccessorBlock(BB10) //Falls through into the default clause followed by another case.
+	BB10.AddSuccessorBlock(BB12)
+	BB11.AddSuccessorBlock(BB12)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestReturnSwitcherBasicBlock(t *testing.T) {
 	srcFile, err := ioutil.ReadFile("./testcode/_returnswitcher.go")
 	if err != nil {
@@ -270,32 +510,62 @@ func TestReturnSwitcherBasicBlock(t *testing.T) {
 	}
 
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
-	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 11)
-	BB2 := bblock.NewBasicBlock(2, bblock.FUNCTION_ENTRY, 13)
-	BB3 := bblock.NewBasicBlock(3, bblock.SWITCH_STATEMENT, 14)
-	BB4 := bblock.NewBasicBlock(4, bblock.RETURN_STMT, 16)
-	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 18)
-	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 20)
-	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 22)
-	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 24)
-	BB9 := bblock.NewBasicBlock(9, bblock.RETURN_STMT, 26)
-	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 28)
-	BB11 := bblock.NewBasicBlock(11, bblock.RETURN_STMT, 30)
-	BB12 := bblock.NewBasicBlock(12, bblock.RETURN_STMT, 32)
-	BB13 := bblock.NewBasicBlock(13, bblock.RETURN_STMT, 34)
-	BB14 := bblock.NewBasicBlock(14, bblock.RETURN_STMT, 36)
-	BB15 := bblock.NewBasicBlock(15, bblock.RETURN_STMT, 38)
-	BB16 := bblock.NewBasicBlock(16, bblock.RETURN_STMT, 40)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.RETURN_STMT, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.FUNCTION_ENTRY, 13)
+	BB4 := bblock.NewBasicBlock(4, bblock.SWITCH_STATEMENT, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 16)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 18)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 20)
+	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 22)
+	BB9 := bblock.NewBasicBlock(9, bblock.RETURN_STMT, 24)
+	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 26)
+	BB11 := bblock.NewBasicBlock(11, bblock.RETURN_STMT, 28)
+	BB12 := bblock.NewBasicBlock(12, bblock.RETURN_STMT, 30)
+	BB13 := bblock.NewBasicBlock(13, bblock.RETURN_STMT, 32)
+	BB14 := bblock.NewBasicBlock(14, bblock.RETURN_STMT, 34)
+	BB15 := bblock.NewBasicBlock(15, bblock.RETURN_STMT, 36)
+	BB16 := bblock.NewBasicBlock(16, bblock.RETURN_STMT, 38)
+	BB17 := bblock.NewBasicBlock(17, bblock.RETURN_STMT, 40)
 
 	// Function main.
 	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
 
 	// Function monthNumberToString.
-	BB2.AddSuccessorBlock(BB3)
-	BB3.AddSuccessorBlock(BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12, BB13, BB14, BB15, BB16)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12, BB13, BB14, BB15, BB16, BB17)
 
 	correctBasicBlocks := []*bblock.BasicBlock{
-		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12, BB13, BB14, BB15, BB16,
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12, BB13, BB14, BB15, BB16, BB17,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCaseReturnBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_casereturn.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.SWITCH_STATEMENT, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.RETURN_STMT, 12)
+	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 16)
+	BB4 := bblock.NewBasicBlock(4, bblock.RETURN_STMT, 18)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3, BB4)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4,
 	}
 
 	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
@@ -330,9 +600,9 @@ func TestNestedSwitchBasicBlock(t *testing.T) {
 	}
 
 	BB0.AddSuccessorBlock(BB1)
-	BB1.AddSuccessorBlock(BB2, BB3, BB7, BB8, BB9, BB10)
+	BB1.AddSuccessorBlock(BB2, BB3, BB7, BB8, BB9)
 	BB2.AddSuccessorBlock(BB10)
-	BB3.AddSuccessorBlock(BB4, BB5, BB6, BB10)
+	BB3.AddSuccessorBlock(BB4, BB5, BB6)
 	BB4.AddSuccessorBlock(BB10)
 	BB5.AddSuccessorBlock(BB10)
 	BB6.AddSuccessorBlock(BB10)
@@ -345,6 +615,102 @@ func TestNestedSwitchBasicBlock(t *testing.T) {
 	}
 }
 
+func TestSwitchInSwitchBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switchinswitch.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.SWITCH_STATEMENT, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.SWITCH_STATEMENT, 12)
+	BB3 := bblock.NewBasicBlock(3, bblock.CASE_CLAUSE, 14)
+	BB4 := bblock.NewBasicBlock(4, bblock.CASE_CLAUSE, 16)
+	BB5 := bblock.NewBasicBlock(5, bblock.CASE_CLAUSE, 19)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 21)
+	BB7 := bblock.NewBasicBlock(7, bblock.FUNCTION_ENTRY, 24)
+	BB8 := bblock.NewBasicBlock(8, bblock.SWITCH_STATEMENT, 25)
+	BB9 := bblock.NewBasicBlock(9, bblock.SWITCH_STATEMENT, 27)
+	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 29)
+	BB11 := bblock.NewBasicBlock(11, bblock.RETURN_STMT, 31)
+	BB12 := bblock.NewBasicBlock(12, bblock.RETURN_STMT, 33)
+	BB13 := bblock.NewBasicBlock(13, bblock.RETURN_STMT, 35)
+	BB14 := bblock.NewBasicBlock(14, bblock.RETURN_STMT, 37)
+	BB15 := bblock.NewBasicBlock(15, bblock.FUNCTION_ENTRY, 40)
+	BB16 := bblock.NewBasicBlock(16, bblock.CALL_EXPRESSION, 41)
+	BB17 := bblock.NewBasicBlock(17, bblock.RETURN_STMT, 42)
+
+	//Function 'classify', the outer switch links its own cases, not the cases of the inner switch.
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB5, BB6)
+	BB2.AddSuccessorBlock(BB3, BB4, BB6)
+	BB3.AddSuccessorBlock(BB6)
+	BB4.AddSuccessorBlock(BB6)
+	BB5.AddSuccessorBlock(BB6)
+
+	//Function 'describe', without a matching case the inner switch continues in its case of the outer switch.
+	BB7.AddSuccessorBlock(BB8)
+	BB8.AddSuccessorBlock(BB9, BB13, BB14)
+	BB9.AddSuccessorBlock(BB10, BB11, BB12)
+
+	BB15.AddSuccessorBlock(BB16)
+	BB16.AddSuccessorBlock(BB17)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12, BB13, BB14, BB15, BB16, BB17,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCaseLoopBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_caseloop.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The case clause starting with a loop or an if shares basic-block with its header.
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.SWITCH_STATEMENT, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.FOR_STATEMENT, 12)
+	BB3 := bblock.NewBasicBlock(3, bblock.FOR_BODY, 14)
+	BB4 := bblock.NewBasicBlock(4, bblock.IF_CONDITION, 16)
+	BB5 := bblock.NewBasicBlock(5, bblock.IF_BODY, 18)
+	BB6 := bblock.NewBasicBlock(6, bblock.CASE_CLAUSE, 20)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 22)
+	BB8 := bblock.NewBasicBlock(8, bblock.FUNCTION_ENTRY, 25)
+	BB9 := bblock.NewBasicBlock(9, bblock.CALL_EXPRESSION, 26)
+	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 27)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB4, BB6)
+	BB2.AddSuccessorBlock(BB3, BB7)
+	BB3.AddSuccessorBlock(BB2)
+	BB4.AddSuccessorBlock(BB5, BB7)
+	BB5.AddSuccessorBlock(BB7)
+	BB6.AddSuccessorBlock(BB7)
+	BB8.AddSuccessorBlock(BB9)
+	BB9.AddSuccessorBlock(BB10)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestTypeSwitchBasicBlock(t *testing.T) {
 	srcFile, err := ioutil.ReadFile("./testcode/_typeswitch.go")
 	if err != nil {
@@ -362,24 +728,20 @@ func TestTypeSwitchBasicBlock(t *testing.T) {
 	BB4 := bblock.NewBasicBlock(4, bblock.CASE_CLAUSE, 21)
 	BB5 := bblock.NewBasicBlock(5, bblock.CASE_CLAUSE, 23)
 	BB6 := bblock.NewBasicBlock(6, bblock.CASE_CLAUSE, 25)
-	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 28)
+	BB7 := bblock.NewBasicBlock(7, bblock.CALL_EXPRESSION, 27)
+	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 28)
 
 	BB0.AddSuccessorBlock(BB1)
-
-	BB1.AddSuccessorBlock(BB2)
-	BB1.AddSuccessorBlock(BB3)
-	BB1.AddSuccessorBlock(BB4)
-	BB1.AddSuccessorBlock(BB5)
-	BB1.AddSuccessorBlock(BB6)
-
+	BB1.AddSuccessorBlock(BB2, BB3, BB4, BB5, BB6)
 	BB2.AddSuccessorBlock(BB7)
 	BB3.AddSuccessorBlock(BB7)
 	BB4.AddSuccessorBlock(BB7)
 	BB5.AddSuccessorBlock(BB7)
 	BB6.AddSuccessorBlock(BB7)
+	BB7.AddSuccessorBlock(BB8)
 
 	correctBasicBlocks := []*bblock.BasicBlock{
-		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7,
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8,
 	}
 
 	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
@@ -405,7 +767,7 @@ func TestSimpleLooperSwitch(t *testing.T) {
 	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 20)
 
 	BB0.AddSuccessorBlock(BB1)
-	BB1.AddSuccessorBlock(BB2, BB5)
+	BB1.AddSuccessorBlock(BB2)
 	BB2.AddSuccessorBlock(BB1, BB3, BB4)
 	BB3.AddSuccessorBlock(BB1)
 	BB4.AddSuccessorBlock(BB1)
@@ -431,20 +793,29 @@ func TestSelectBasicBlock(t *testing.T) {
 
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 12)
 	BB1 := bblock.NewBasicBlock(1, bblock.GO_STATEMENT, 16)
-	BB2 := bblock.NewBasicBlock(2, bblock.FOR_STATEMENT, 24)
-	BB3 := bblock.NewBasicBlock(3, bblock.SELECT_STATEMENT, 26)
-	BB4 := bblock.NewBasicBlock(4, bblock.COMM_CLAUSE, 28)
-	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 31)
-	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 34)
+	BB2 := bblock.NewBasicBlock(2, bblock.CALL_EXPRESSION, 22)
+	BB3 := bblock.NewBasicBlock(3, bblock.FOR_STATEMENT, 24)
+	BB4 := bblock.NewBasicBlock(4, bblock.SELECT_STATEMENT, 26)
+	BB5 := bblock.NewBasicBlock(5, bblock.COMM_CLAUSE, 28)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 31)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 34)
+	BB8 := bblock.NewBasicBlock(8, bblock.FUNCTION_ENTRY, 16)
+	BB9 := bblock.NewBasicBlock(9, bblock.CALL_EXPRESSION, 18)
+	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 20)
 
 	BB0.AddSuccessorBlock(BB1)
 	BB1.AddSuccessorBlock(BB2)
-	BB2.AddSuccessorBlock(BB3, BB6)
-	BB3.AddSuccessorBlock(BB2, BB4, BB5)
-	BB4.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB5, BB6)
+	BB5.AddSuccessorBlock(BB3)
+
+	// Function literal started by the go statement.
+	BB8.AddSuccessorBlock(BB9)
+	BB9.AddSuccessorBlock(BB10)
 
 	correctBasicBlocks := []*bblock.BasicBlock{
-		BB0, BB1, BB2, BB3, BB4, BB5, BB6,
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10,
 	}
 
 	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
@@ -452,34 +823,1300 @@ func TestSelectBasicBlock(t *testing.T) {
 	}
 }
 
-func TestGreatestCommonDivisor(t *testing.T) {
-	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+func TestEmptySelectBasicBlock(t *testing.T) {
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode([]byte("package main\n\nfunc main() {\n\tselect {}\n}\n"))
 	if err != nil {
 		t.Fatal(err)
 	}
+
+	//A select statement without clauses blocks forever, never reaching the end of the function.
+	selectBlock := basicBlocks[1]
+	if selectBlock.Type != bblock.SELECT_STATEMENT {
+		t.Fatalf("Basic block nr. 1 should be of type %s, but are of type %s!\n", bblock.SELECT_STATEMENT, selectBlock.Type)
+	}
+	if successors := selectBlock.GetSuccessorBlocks(); len(successors) != 0 {
+		t.Errorf("Select statement without clauses should not have successors, but has %v!\n", successors)
+	}
+}
+
+func TestOneLinerBasicBlock(t *testing.T) {
+	srcFile := []byte("package main\n\nfunc sum() int { return g() + h() }\n\nfunc main() {\n\tsum()\n}\n")
 	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
-	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 12)
-	BB2 := bblock.NewBasicBlock(2, bblock.FUNCTION_ENTRY, 14)
-	BB3 := bblock.NewBasicBlock(3, bblock.FOR_STATEMENT, 16)
-	BB4 := bblock.NewBasicBlock(4, bblock.FOR_BODY, 19)
-	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 20)
+	//The return statement of the function on a single line gets a block of its own, following the entry block.
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 3)
+	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 3)
+	BB2 := bblock.NewBasicBlock(2, bblock.FUNCTION_ENTRY, 5)
+	BB3 := bblock.NewBasicBlock(3, bblock.CALL_EXPRESSION, 6)
+	BB4 := bblock.NewBasicBlock(4, bblock.RETURN_STMT, 7)
 
 	BB0.AddSuccessorBlock(BB1)
 	BB2.AddSuccessorBlock(BB3)
 	BB3.AddSuccessorBlock(BB4)
-	BB3.AddSuccessorBlock(BB5)
-	BB4.AddSuccessorBlock(BB3)
 
 	correctBasicBlocks := []*bblock.BasicBlock{
-		BB0, BB1, BB2, BB3, BB4, BB5,
+		BB0, BB1, BB2, BB3, BB4,
 	}
 
 	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
 		t.Fatal(err)
 	}
+	if expectedBasicBlocks[0].FunctionName != "sum" {
+		t.Errorf("Basic block nr. 0 should enter function sum, and not %q!\n", expectedBasicBlocks[0].FunctionName)
+	}
+}
+
+func TestOneLinerDecisionBasicBlock(t *testing.T) {
+	srcFile := []byte("package main\n\nfunc abs(x int) int { if x < 0 { return -x }; return x }\n\nfunc both() { g(); h() }\n")
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The statements on the line of the function get blocks of their own, in source order.
+	testCases := []struct {
+		blockType  bblock.BasicBlockType
+		successors []int
+	}{
+		{bblock.FUNCTION_ENTRY, []int{1}},
+		{bblock.IF_CONDITION, []int{2, 3}},
+		{bblock.RETURN_STMT, []int{}},
+		{bblock.RETURN_STMT, []int{}},
+		{bblock.FUNCTION_ENTRY, []int{5}},
+		{bblock.CALL_EXPRESSION, []int{6}},
+		{bblock.CALL_EXPRESSION, []int{7}},
+		{bblock.RETURN_STMT, []int{}},
+	}
+	if len(expectedBasicBlocks) != len(testCases) {
+		t.Fatalf("Number of basic-blocks should be %d, but are %d!\n", len(testCases), len(expectedBasicBlocks))
+	}
+	for index, testCase := range testCases {
+		basicBlock := expectedBasicBlocks[index]
+		if basicBlock.Type != testCase.blockType {
+			t.Errorf("Basic-block nr. %d should be of type %s, but are of type %s!\n", index, testCase.blockType, basicBlock.Type)
+		}
+		successors := []int{}
+		for _, successor := range basicBlock.GetSuccessorBlocks() {
+			successors = append(successors, successor.Number)
+		}
+		if !reflect.DeepEqual(successors, testCase.successors) {
+			t.Errorf("Basic-block nr. %d should have successors %v, and not %v!\n", index, testCase.successors, successors)
+		}
+	}
+}
+
+func TestGreatestCommonDivisor(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.CALL_EXPRESSION, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 12)
+	BB4 := bblock.NewBasicBlock(4, bblock.FUNCTION_ENTRY, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.FOR_STATEMENT, 16)
+	BB6 := bblock.NewBasicBlock(6, bblock.FOR_BODY, 19)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 20)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3)
+	BB4.AddSuccessorBlock(BB5)
+	BB5.AddSuccessorBlock(BB6, BB7)
+	BB6.AddSuccessorBlock(BB5)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFallthroughSuccessor(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_sequential.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		blockNumber     int
+		hasFallthrough  bool
+		successorNumber int
+	}{
+		{0, true, 1},
+		{1, true, 2},
+		{2, false, 0}, //Both successors of the if-condition are branch targets.
+		{3, false, 0}, //The if-body jumps past the else-body.
+		{5, true, 6},
+		{6, false, 0},
+	}
+
+	for _, testCase := range testCases {
+		successorBlock, ok := basicBlocks[testCase.blockNumber].FallthroughSuccessor()
+		if ok != testCase.hasFallthrough {
+			t.Errorf("Basic block nr. %d should have fall-through successor %t, but has %t!\n",
+				testCase.blockNumber, testCase.hasFallthrough, ok)
+		} else if ok && successorBlock.Number != testCase.successorNumber {
+			t.Errorf("Fall-through successor of basic block nr. %d should be nr. %d, and not nr. %d!\n",
+				testCase.blockNumber, testCase.successorNumber, successorBlock.Number)
+		}
+	}
+}
+
+func TestTrueFalseSuccessor(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_truefalse.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ifBlock := basicBlocks[1]
+	if ifBlock.Type != bblock.IF_CONDITION {
+		t.Fatalf("Basic block nr. 1 should be of type %s, but are of type %s!\n", bblock.IF_CONDITION, ifBlock.Type)
+	}
+
+	if trueBlock := ifBlock.TrueSuccessor(); trueBlock == nil || trueBlock.Number != 2 {
+		t.Errorf("True successor of basic block nr. 1 should be nr. 2, and not %v!\n", trueBlock)
+	}
+	if falseBlock := ifBlock.FalseSuccessor(); falseBlock == nil || falseBlock.Number != 4 {
+		t.Errorf("False successor of basic block nr. 1 should be nr. 4, and not %v!\n", falseBlock)
+	}
+
+	//Blocks not branching on a condition have no labeled successors.
+	if basicBlocks[0].TrueSuccessor() != nil || basicBlocks[0].FalseSuccessor() != nil {
+		t.Error("Basic block nr. 0 should not have any true or false successor!")
+	}
+}
+
+func TestSuccessorEdges(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcdBasicBlocks, err := bblock.GetBasicBlocksForFunction(srcFile, "gcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The loop header enters the body or leaves the loop.
+	forBlock := gcdBasicBlocks[1]
+	if forBlock.Type != bblock.FOR_STATEMENT {
+		t.Fatalf("Basic block nr. 1 should be of type %s, but are of type %s!\n", bblock.FOR_STATEMENT, forBlock.Type)
+	}
+	correctEdges := []bblock.SuccessorEdge{
+		{Target: gcdBasicBlocks[2], Label: bblock.LOOP_EDGE},
+		{Target: gcdBasicBlocks[3], Label: bblock.EXIT_EDGE},
+	}
+	if edges := forBlock.GetSuccessorEdges(); !reflect.DeepEqual(edges, correctEdges) {
+		t.Errorf("Successor edges of the gcd loop should be %v, and not %v!\n", correctEdges, edges)
+	}
+
+	//Successor edges follow the successor blocks.
+	for _, basicBlock := range gcdBasicBlocks {
+		edges := basicBlock.GetSuccessorEdges()
+		successors := basicBlock.GetSuccessorBlocks()
+		if len(edges) != len(successors) {
+			t.Fatalf("Number of successor edges of %s should be %d, but are %d!\n", basicBlock, len(successors), len(edges))
+		}
+		for index, edge := range edges {
+			if edge.Target != successors[index] {
+				t.Errorf("Successor edge nr. %d of %s should lead to %s, and not %s!\n", index, basicBlock,
+					successors[index], edge.Target)
+			}
+		}
+	}
+
+	srcFile, err = ioutil.ReadFile("./testcode/_switch.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Edges to the case clauses are labeled with the case values, with a default clause there is no edge past the switch.
+	correctLabels := []string{"0", "1", "2", "3", "4", bblock.DEFAULT_EDGE}
+	edges := basicBlocks[1].GetSuccessorEdges()
+	if len(edges) != len(correctLabels) {
+		t.Fatalf("Number of successor edges of the switch should be %d, but are %d!\n", len(correctLabels), len(edges))
+	}
+	for index, edge := range edges {
+		if edge.Label != correctLabels[index] {
+			t.Errorf("Successor edge to %s should be labeled %q, and not %q!\n", edge.Target, correctLabels[index], edge.Label)
+		}
+	}
+}
+
+func TestInfiniteLoopBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_infiniteloop.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 11)
+	BB2 := bblock.NewBasicBlock(2, bblock.IF_CONDITION, 14)
+	BB3 := bblock.NewBasicBlock(3, bblock.CALL_EXPRESSION, 16)
+	BB4 := bblock.NewBasicBlock(4, bblock.ELSE_CONDITION, 17)
+	BB5 := bblock.NewBasicBlock(5, bblock.ELSE_BODY, 20)
+	BB6 := bblock.NewBasicBlock(6, bblock.CALL_EXPRESSION, 22)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 23)
+	BB8 := bblock.NewBasicBlock(8, bblock.FUNCTION_ENTRY, 25)
+	BB9 := bblock.NewBasicBlock(9, bblock.FOR_STATEMENT, 27)
+	BB10 := bblock.NewBasicBlock(10, bblock.CALL_EXPRESSION, 29)
+	BB11 := bblock.NewBasicBlock(11, bblock.FOR_BODY, 30)
+	BB12 := bblock.NewBasicBlock(12, bblock.RETURN_STMT, 31)
+
+	// Function main, loop is left through break.
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3, BB5)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB1)
+	BB5.AddSuccessorBlock(BB6) //The else body ends in break.
+	BB6.AddSuccessorBlock(BB7)
+
+	// Function spin, loop is never left.
+	BB8.AddSuccessorBlock(BB9)
+	BB9.AddSuccessorBlock(BB10)
+	BB10.AddSuccessorBlock(BB11)
+	BB11.AddSuccessorBlock(BB9)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoopBreakBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_loopbreak.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.IF_CONDITION, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.BREAK_STMT, 12)
+	BB4 := bblock.NewBasicBlock(4, bblock.RETURN_STMT, 16)
+	BB5 := bblock.NewBasicBlock(5, bblock.FUNCTION_ENTRY, 19)
+	BB6 := bblock.NewBasicBlock(6, bblock.CALL_EXPRESSION, 20)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 21)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB1, BB3)
+	BB3.AddSuccessorBlock(BB4) //The loop without condition is left through break only.
+	BB5.AddSuccessorBlock(BB6)
+	BB6.AddSuccessorBlock(BB7)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLabeledCaseBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_labeledcase.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.LABELED_STMT, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.FOR_STATEMENT, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.SELECT_STATEMENT, 12)
+	BB4 := bblock.NewBasicBlock(4, bblock.BREAK_STMT, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.COMM_CLAUSE, 16)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 19)
+	BB7 := bblock.NewBasicBlock(7, bblock.FUNCTION_ENTRY, 22)
+	BB8 := bblock.NewBasicBlock(8, bblock.LABELED_STMT, 24)
+	BB9 := bblock.NewBasicBlock(9, bblock.FOR_STATEMENT, 25)
+	BB10 := bblock.NewBasicBlock(10, bblock.SWITCH_STATEMENT, 26)
+	BB11 := bblock.NewBasicBlock(11, bblock.CONTINUE_STMT, 28)
+	BB12 := bblock.NewBasicBlock(12, bblock.BREAK_STMT, 30)
+	BB13 := bblock.NewBasicBlock(13, bblock.RETURN_STMT, 34)
+	BB14 := bblock.NewBasicBlock(14, bblock.FUNCTION_ENTRY, 37)
+	BB15 := bblock.NewBasicBlock(15, bblock.CALL_EXPRESSION, 38)
+	BB16 := bblock.NewBasicBlock(16, bblock.RETURN_STMT, 39)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3)
+	BB3.AddSuccessorBlock(BB4, BB5)
+	BB4.AddSuccessorBlock(BB6) //The labeled break leaves the loop only.
+	BB5.AddSuccessorBlock(BB2)
+	BB7.AddSuccessorBlock(BB8)
+	BB8.AddSuccessorBlock(BB9)
+	BB9.AddSuccessorBlock(BB10, BB13)
+	BB10.AddSuccessorBlock(BB9, BB11, BB12)
+	BB11.AddSuccessorBlock(BB9)
+	BB12.AddSuccessorBlock(BB13) //The labeled break leaves the loop only.
+	BB14.AddSuccessorBlock(BB15)
+	BB15.AddSuccessorBlock(BB16)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12, BB13, BB14, BB15, BB16,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStatementBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_statements.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lineBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lineBasicBlocks) != 9 {
+		t.Errorf("Number of basic-blocks should be %d, but are %d!\n", 9, len(lineBasicBlocks))
+	}
+
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCodeWithMode(srcFile, bblock.STATEMENT_BLOCKS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.STATEMENT, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.STATEMENT, 10)
+	BB3 := bblock.NewBasicBlock(3, bblock.FOR_STATEMENT, 11)
+	BB4 := bblock.NewBasicBlock(4, bblock.STATEMENT, 12)
+	BB5 := bblock.NewBasicBlock(5, bblock.STATEMENT, 13)
+	BB6 := bblock.NewBasicBlock(6, bblock.FOR_BODY, 14)
+	BB7 := bblock.NewBasicBlock(7, bblock.IF_CONDITION, 15)
+	BB8 := bblock.NewBasicBlock(8, bblock.CALL_EXPRESSION, 16)
+	BB9 := bblock.NewBasicBlock(9, bblock.ELSE_CONDITION, 17)
+	BB10 := bblock.NewBasicBlock(10, bblock.ELSE_BODY, 19)
+	BB11 := bblock.NewBasicBlock(11, bblock.CALL_EXPRESSION, 20)
+	BB12 := bblock.NewBasicBlock(12, bblock.RETURN_STMT, 21)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3)
+	BB3.AddSuccessorBlock(BB4, BB7)
+	BB4.AddSuccessorBlock(BB5)
+	BB5.AddSuccessorBlock(BB6)
+	BB6.AddSuccessorBlock(BB3)
+	BB7.AddSuccessorBlock(BB8, BB10)
+	BB8.AddSuccessorBlock(BB9)
+	BB9.AddSuccessorBlock(BB11)
+	BB10.AddSuccessorBlock(BB11)
+	BB11.AddSuccessorBlock(BB12)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestForClauseBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_forclause.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.FOR_BODY, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 12)
+	BB4 := bblock.NewBasicBlock(4, bblock.FUNCTION_ENTRY, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.FOR_STATEMENT, 17)
+	BB6 := bblock.NewBasicBlock(6, bblock.CALL_EXPRESSION, 18)
+	BB7 := bblock.NewBasicBlock(7, bblock.FOR_BODY, 19)
+	BB8 := bblock.NewBasicBlock(8, bblock.CALL_EXPRESSION, 20)
+	BB9 := bblock.NewBasicBlock(9, bblock.RETURN_STMT, 21)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3)
+	BB2.AddSuccessorBlock(BB1)
+	BB4.AddSuccessorBlock(BB5)
+	BB5.AddSuccessorBlock(BB6, BB8)
+	BB6.AddSuccessorBlock(BB7)
+	BB7.AddSuccessorBlock(BB5)
+	BB8.AddSuccessorBlock(BB9)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//The loop header spans from the for keyword to the post statement.
+	headerLines := map[int][2]int{1: {9, 9}, 5: {16, 17}}
+	for index, lines := range headerLines {
+		if expectedBasicBlocks[index].StartLine != lines[0] || expectedBasicBlocks[index].EndLine != lines[1] {
+			t.Errorf("Loop header in basic-block nr. %d should span line %d to %d, but spans %d to %d!\n", index,
+				lines[0], lines[1], expectedBasicBlocks[index].StartLine, expectedBasicBlocks[index].EndLine)
+		}
+	}
+}
+
+func TestCallExpressionBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_calls.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.FOR_STATEMENT, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.CALL_EXPRESSION, 13)
+	BB4 := bblock.NewBasicBlock(4, bblock.FOR_BODY, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.CALL_EXPRESSION, 15)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 16)
+	BB7 := bblock.NewBasicBlock(7, bblock.FUNCTION_ENTRY, 18)
+	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 19)
+
+	// Function main, calls assigned to variables are not bare calls.
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3, BB5)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB2)
+	BB5.AddSuccessorBlock(BB6)
+
+	// Function work.
+	BB7.AddSuccessorBlock(BB8)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStructClosureBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_structclosure.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 13)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 29)
+	BB2 := bblock.NewBasicBlock(2, bblock.RETURN_STMT, 30)
+	BB3 := bblock.NewBasicBlock(3, bblock.FUNCTION_ENTRY, 17)
+	BB4 := bblock.NewBasicBlock(4, bblock.IF_CONDITION, 19)
+	BB5 := bblock.NewBasicBlock(5, bblock.ELSE_CONDITION, 22)
+	BB6 := bblock.NewBasicBlock(6, bblock.ELSE_BODY, 25)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 26)
+
+	// Function main.
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+
+	// Function literal in struct literal.
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB5, BB6)
+	BB5.AddSuccessorBlock(BB7)
+	BB6.AddSuccessorBlock(BB7)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	if expectedBasicBlocks[3].FunctionName != "main$func1" {
+		t.Errorf("Function literal should be named main$func1, and not %s!\n", expectedBasicBlocks[3].FunctionName)
+	}
+}
+
+func TestCallArgumentClosureBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_callargument.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 10)
+	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 12)
+	BB2 := bblock.NewBasicBlock(2, bblock.FUNCTION_ENTRY, 14)
+	BB3 := bblock.NewBasicBlock(3, bblock.CALL_EXPRESSION, 15)
+	BB4 := bblock.NewBasicBlock(4, bblock.CALL_EXPRESSION, 23)
+	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 24)
+	BB6 := bblock.NewBasicBlock(6, bblock.FUNCTION_ENTRY, 15)
+	BB7 := bblock.NewBasicBlock(7, bblock.IF_CONDITION, 16)
+	BB8 := bblock.NewBasicBlock(8, bblock.ELSE_CONDITION, 18)
+	BB9 := bblock.NewBasicBlock(9, bblock.ELSE_BODY, 20)
+	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 21)
+
+	// Function register.
+	BB0.AddSuccessorBlock(BB1)
+
+	// Function main.
+	BB2.AddSuccessorBlock(BB3)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB5)
+
+	// Function literal passed as call argument.
+	BB6.AddSuccessorBlock(BB7)
+	BB7.AddSuccessorBlock(BB8, BB9)
+	BB8.AddSuccessorBlock(BB10)
+	BB9.AddSuccessorBlock(BB10)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	if expectedBasicBlocks[6].FunctionName != "main$func1" {
+		t.Errorf("Function literal should be named main$func1, and not %s!\n", expectedBasicBlocks[6].FunctionName)
+	}
+}
+
+func TestNestedForLoopBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_nestedfor.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 6)
+	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.FOR_STATEMENT, 10)
+	BB3 := bblock.NewBasicBlock(3, bblock.FOR_BODY, 12)
+	BB4 := bblock.NewBasicBlock(4, bblock.CALL_EXPRESSION, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 15)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB4)
+	BB2.AddSuccessorBlock(BB1, BB3) //Inner loop continues in the outer loop header.
+	BB3.AddSuccessorBlock(BB2)
+	BB4.AddSuccessorBlock(BB5)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeferBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_defer.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.DEFER_STATEMENT, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.SWITCH_STATEMENT, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 13)
+	BB4 := bblock.NewBasicBlock(4, bblock.CASE_CLAUSE, 15)
+	BB5 := bblock.NewBasicBlock(5, bblock.DEFER_STATEMENT, 17)
+	BB6 := bblock.NewBasicBlock(6, bblock.CALL_EXPRESSION, 18)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 19)
+	BB8 := bblock.NewBasicBlock(8, bblock.FUNCTION_ENTRY, 22)
+	BB9 := bblock.NewBasicBlock(9, bblock.CALL_EXPRESSION, 24)
+	BB10 := bblock.NewBasicBlock(10, bblock.RETURN_STMT, 25)
+
+	BB0.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3, BB4)
+	BB3.AddSuccessorBlock(BB1)
+	BB4.AddSuccessorBlock(BB6)
+	BB5.AddSuccessorBlock(BB1)
+	BB6.AddSuccessorBlock(BB7)
+	BB7.AddSuccessorBlock(BB5)
+	BB8.AddSuccessorBlock(BB9)
+	BB9.AddSuccessorBlock(BB10)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//Deferred calls run in reverse order on return, and are not entered sequentially.
+	if successor, ok := expectedBasicBlocks[7].FallthroughSuccessor(); ok {
+		t.Errorf("Return block nr. 7 should not fall through, but falls through to %s!\n", successor)
+	}
+	if successor := expectedBasicBlocks[7].LastSuccessor; successor != expectedBasicBlocks[5] {
+		t.Errorf("Return block nr. 7 should run the deferred call nr. 5, and not %v!\n", successor)
+	}
+	if successor := expectedBasicBlocks[3].LastSuccessor; successor != expectedBasicBlocks[1] {
+		t.Errorf("Early return block nr. 3 should only run the deferred call nr. 1, and not %v!\n", successor)
+	}
+}
+
+func TestGotoBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_goto.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.FUNCTION_ENTRY, 13)
+	BB3 := bblock.NewBasicBlock(3, bblock.LABELED_STMT, 16)
+	BB4 := bblock.NewBasicBlock(4, bblock.IF_CONDITION, 19)
+	BB5 := bblock.NewBasicBlock(5, bblock.GOTO_STATEMENT, 20)
+	BB6 := bblock.NewBasicBlock(6, bblock.ELSE_CONDITION, 21)
+	BB7 := bblock.NewBasicBlock(7, bblock.ELSE_BODY, 23)
+	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 24)
+	BB9 := bblock.NewBasicBlock(9, bblock.FUNCTION_ENTRY, 27)
+	BB10 := bblock.NewBasicBlock(10, bblock.FOR_STATEMENT, 29)
+	BB11 := bblock.NewBasicBlock(11, bblock.IF_CONDITION, 31)
+	BB12 := bblock.NewBasicBlock(12, bblock.GOTO_STATEMENT, 32)
+	BB13 := bblock.NewBasicBlock(13, bblock.ELSE_CONDITION, 33)
+	BB14 := bblock.NewBasicBlock(14, bblock.ELSE_BODY, 35)
+	BB15 := bblock.NewBasicBlock(15, bblock.RETURN_STMT, 37)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB2.AddSuccessorBlock(BB3)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB5, BB7)
+	BB5.AddSuccessorBlock(BB3)
+	BB6.AddSuccessorBlock(BB8)
+	BB7.AddSuccessorBlock(BB8)
+	BB9.AddSuccessorBlock(BB10)
+	BB10.AddSuccessorBlock(BB11, BB15)
+	BB11.AddSuccessorBlock(BB12, BB14)
+	BB12.AddSuccessorBlock(BB10)
+	BB13.AddSuccessorBlock(BB10)
+	BB14.AddSuccessorBlock(BB10)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12, BB13, BB14, BB15,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//A goto jumping back to a label on the line of a loop reaches the loop header, not a block of its own.
+	if successor := expectedBasicBlocks[12].LastSuccessor; successor != expectedBasicBlocks[10] {
+		t.Errorf("Goto block nr. 12 should jump to loop header nr. 10, and not %v!\n", successor)
+	}
+}
+
+func TestBreakContinueBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_breakcontinue.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.SWITCH_STATEMENT, 10)
+	BB3 := bblock.NewBasicBlock(3, bblock.BREAK_STMT, 13)
+	BB4 := bblock.NewBasicBlock(4, bblock.CASE_CLAUSE, 15)
+	BB5 := bblock.NewBasicBlock(5, bblock.IF_CONDITION, 17)
+	BB6 := bblock.NewBasicBlock(6, bblock.CONTINUE_STMT, 18)
+	BB7 := bblock.NewBasicBlock(7, bblock.ELSE_CONDITION, 19)
+	BB8 := bblock.NewBasicBlock(8, bblock.ELSE_BODY, 21)
+	BB9 := bblock.NewBasicBlock(9, bblock.CALL_EXPRESSION, 22)
+	BB10 := bblock.NewBasicBlock(10, bblock.FOR_BODY, 23)
+	BB11 := bblock.NewBasicBlock(11, bblock.CALL_EXPRESSION, 24)
+	BB12 := bblock.NewBasicBlock(12, bblock.RETURN_STMT, 25)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB11)
+	BB2.AddSuccessorBlock(BB1, BB3, BB4)
+	BB3.AddSuccessorBlock(BB5)
+	BB4.AddSuccessorBlock(BB1, BB5)
+	BB5.AddSuccessorBlock(BB6, BB8)
+	BB6.AddSuccessorBlock(BB1)
+	BB7.AddSuccessorBlock(BB9)
+	BB8.AddSuccessorBlock(BB9)
+	BB9.AddSuccessorBlock(BB10)
+	BB10.AddSuccessorBlock(BB1)
+	BB11.AddSuccessorBlock(BB12)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9, BB10, BB11, BB12,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//Break inside a switch nested in a loop leaves the switch, not the loop.
+	if successor := expectedBasicBlocks[3].LastSuccessor; successor != expectedBasicBlocks[5] {
+		t.Errorf("Break block nr. 3 should jump to block nr. 5 after the switch, and not %v!\n", successor)
+	}
+	if successor := expectedBasicBlocks[6].LastSuccessor; successor != expectedBasicBlocks[1] {
+		t.Errorf("Continue block nr. 6 should jump to loop header nr. 1, and not %v!\n", successor)
+	}
+}
+
+func TestEmptyStatementBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_emptystatements.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 11)
+	BB2 := bblock.NewBasicBlock(2, bblock.FOR_BODY, 14)
+	BB3 := bblock.NewBasicBlock(3, bblock.IF_CONDITION, 15)
+	BB4 := bblock.NewBasicBlock(4, bblock.ELSE_CONDITION, 17)
+	BB5 := bblock.NewBasicBlock(5, bblock.ELSE_BODY, 19)
+	BB6 := bblock.NewBasicBlock(6, bblock.CALL_EXPRESSION, 23)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 24)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3)
+	BB2.AddSuccessorBlock(BB1)
+	BB3.AddSuccessorBlock(BB4, BB5)
+	BB4.AddSuccessorBlock(BB6)
+	BB5.AddSuccessorBlock(BB6)
+	BB6.AddSuccessorBlock(BB7)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//Stray semicolons and empty blocks get no basic-block, also when every statement line does.
+	statementBasicBlocks, err := bblock.GetBasicBlocksFromSourceCodeWithMode(srcFile, bblock.STATEMENT_BLOCKS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, basicBlock := range statementBasicBlocks {
+		switch basicBlock.EndLine {
+		case 10, 12, 16, 20, 21, 22:
+			t.Errorf("Empty statement at line %d should not have a basic-block, but has %s!\n", basicBlock.EndLine,
+				basicBlock.Type)
+		}
+	}
+}
+
+func TestBlockComments(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_blockcomments.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCodeWithMode(srcFile, bblock.BLOCK_COMMENTS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Each basic-block is annotated with its number, as in "// BB #1 ending.".
+	annotation := regexp.MustCompile(`BB #(\d+) ending\.`)
+	for _, basicBlock := range basicBlocks {
+		match := annotation.FindStringSubmatch(basicBlock.Comment)
+		if match == nil {
+			t.Errorf("Basic block nr. %d should be annotated, but has comment %q!\n", basicBlock.Number, basicBlock.Comment)
+			continue
+		}
+		if number, _ := strconv.Atoi(match[1]); number != basicBlock.Number {
+			t.Errorf("Basic block nr. %d should be annotated with its number, and not %d!\n", basicBlock.Number, number)
+		}
+	}
+
+	//Several comments on the line of a basic-block are joined.
+	if basicBlocks[1].Comment != "on number BB #1 ending." {
+		t.Errorf("Comment of basic block nr. 1 should be %q, and not %q!\n", "on number BB #1 ending.", basicBlocks[1].Comment)
+	}
+
+	//Comments are only kept when asked for.
+	basicBlocks, err = bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, basicBlock := range basicBlocks {
+		if basicBlock.Comment != "" {
+			t.Errorf("Basic block nr. %d should have no comment, and not %q!\n", basicBlock.Number, basicBlock.Comment)
+		}
+	}
+}
+
+func TestFunctionBasicBlocks(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_closures.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	functions, err := bblock.GetFunctionBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numberOfBasicBlocks := map[string]int{
+		"main":             3,
+		"main$func1":       2, //Closure assigned to a variable.
+		"main$func2":       3, //Closure started by the go statement.
+		"main$func2$func1": 4, //Closure nested in a closure.
+	}
+	if len(functions) != len(numberOfBasicBlocks) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(numberOfBasicBlocks), len(functions))
+	}
+	for name, count := range numberOfBasicBlocks {
+		if len(functions[name]) != count {
+			t.Errorf("Number of basic-blocks in %s should be %d, but are %d!\n", name, count, len(functions[name]))
+		}
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 14)
+	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 16)
+	BB2 := bblock.NewBasicBlock(2, bblock.FOR_BODY, 18)
+	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 19)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3)
+	BB2.AddSuccessorBlock(BB1)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3,
+	}
+
+	if err := verifyBasicBlocks(functions["main$func2$func1"], correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMethodFunctionBasicBlocks(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_receivers.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	functions, err := bblock.GetFunctionBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numberOfBasicBlocks := map[string]int{
+		"celsius.String":        4, //Value receiver.
+		"(*point).String":       2, //Pointer receiver.
+		"(*point).String$func1": 2, //Closure in a method.
+		"(*point).Reset":        2, //Unnamed receiver.
+		"String":                2, //Plain function.
+		"main":                  3,
+	}
+	if len(functions) != len(numberOfBasicBlocks) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(numberOfBasicBlocks), len(functions))
+	}
+	for name, count := range numberOfBasicBlocks {
+		if len(functions[name]) != count {
+			t.Errorf("Number of basic-blocks in %s should be %d, but are %d!\n", name, count, len(functions[name]))
+		}
+	}
+}
+
+func TestBasicBlocksForFunction(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainBasicBlocks, err := bblock.GetBasicBlocksForFunction(srcFile, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.CALL_EXPRESSION, 11)
+	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 12)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3)
+
+	if err := verifyBasicBlocks(mainBasicBlocks, []*bblock.BasicBlock{BB0, BB1, BB2, BB3}); err != nil {
+		t.Fatal(err)
+	}
+
+	gcdBasicBlocks, err := bblock.GetBasicBlocksForFunction(srcFile, "gcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 = bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 14)
+	BB1 = bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 16)
+	BB2 = bblock.NewBasicBlock(2, bblock.FOR_BODY, 19)
+	BB3 = bblock.NewBasicBlock(3, bblock.RETURN_STMT, 20)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2, BB3)
+	BB2.AddSuccessorBlock(BB1)
+
+	if err := verifyBasicBlocks(gcdBasicBlocks, []*bblock.BasicBlock{BB0, BB1, BB2, BB3}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := bblock.GetBasicBlocksForFunction(srcFile, "lcm"); err == nil {
+		t.Error("Function lcm does not exist and should give an error!")
+	}
+}
+
+func TestBasicBlocksFromReader(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readerBasicBlocks, err := bblock.GetBasicBlocksFromReader(strings.NewReader(string(srcFile)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyBasicBlocks(readerBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	namedReaderBasicBlocks, err := bblock.GetBasicBlocksFromNamedReader("gcd.go", strings.NewReader(string(srcFile)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyBasicBlocks(namedReaderBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//Parse errors are reported in the named file.
+	_, err = bblock.GetBasicBlocksFromNamedReader("broken.go", strings.NewReader("package main\n\nfunc main() {\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "broken.go:3:") {
+		t.Errorf("Error parsing broken.go should be reported at broken.go:3, and not as %v!\n", err)
+	}
+}
+
+func TestBasicBlocksForMethod(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_methods.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name                string
+		line                int
+		numberOfBasicBlocks int
+	}{
+		{"square.Area", 17, 2},
+		{"(*shape).Area", 21, 5},
+		{"Area", 17, 2}, //First method with the name.
+	}
+
+	for _, testCase := range testCases {
+		basicBlocks, err := bblock.GetBasicBlocksForFunction(srcFile, testCase.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(basicBlocks) != testCase.numberOfBasicBlocks {
+			t.Errorf("Number of basic-blocks in %s should be %d, but are %d!\n", testCase.name,
+				testCase.numberOfBasicBlocks, len(basicBlocks))
+		} else if basicBlocks[0].EndLine != testCase.line || basicBlocks[0].Number != 0 {
+			t.Errorf("Basic-block nr. 0 of %s should start the function at line %d, but is %v!\n", testCase.name,
+				testCase.line, basicBlocks[0])
+		}
+	}
+
+	if _, err := bblock.GetBasicBlocksForFunction(srcFile, "(*square).Area"); err == nil {
+		t.Error("Method Area has a value receiver, and (*square).Area should give an error!")
+	}
+}
+
+func TestBareBlockBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_bareblock.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.IF_CONDITION, 12)
+	BB3 := bblock.NewBasicBlock(3, bblock.CALL_EXPRESSION, 13)
+	BB4 := bblock.NewBasicBlock(4, bblock.ELSE_CONDITION, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.ELSE_BODY, 16)
+	BB6 := bblock.NewBasicBlock(6, bblock.CALL_EXPRESSION, 18)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 19)
+
+	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
+	BB2.AddSuccessorBlock(BB3, BB5)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB6)
+	BB5.AddSuccessorBlock(BB6)
+	BB6.AddSuccessorBlock(BB7)
+
+	correctBasicBlocks := []*bblock.BasicBlock{
+		BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7,
+	}
+
+	if err := verifyBasicBlocks(expectedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTypeSwitchGuardBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_typeswitchguard.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Each case binds its own guard variable, the case bodies must stay separate blocks.
+	correctCaseBlocks := []*bblock.BasicBlock{
+		bblock.NewBasicBlock(2, bblock.RETURN_STMT, 13),
+		bblock.NewBasicBlock(3, bblock.CASE_CLAUSE, 15),
+		bblock.NewBasicBlock(4, bblock.RANGE_STATEMENT, 17),
+		bblock.NewBasicBlock(7, bblock.CASE_CLAUSE, 21),
+	}
+
+	switchBlock := basicBlocks[1]
+	if switchBlock.Type != bblock.SWITCH_STATEMENT {
+		t.Fatalf("Basic block nr. 1 should be of type %s, but are of type %s!\n", bblock.SWITCH_STATEMENT, switchBlock.Type)
+	}
+
+	successors := switchBlock.GetSuccessorBlocks()
+	for index, correctBlock := range correctCaseBlocks {
+		if index >= len(successors) {
+			t.Fatalf("Switch should have case block nr. %d as successor!\n", correctBlock.Number)
+		}
+		caseBlock := successors[index]
+		if caseBlock.Number != correctBlock.Number || caseBlock.Type != correctBlock.Type ||
+			caseBlock.EndLine != correctBlock.EndLine {
+			t.Errorf("Case block should be %s, and not %s!\n", correctBlock, caseBlock)
+		}
+	}
+}
+
+func TestCaseRangeBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_caserange.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The case starting with a range loop shares basic-block with the loop header.
+	correctCaseBlocks := []*bblock.BasicBlock{
+		bblock.NewBasicBlock(2, bblock.RANGE_STATEMENT, 12),
+		bblock.NewBasicBlock(4, bblock.CASE_CLAUSE, 17),
+	}
+
+	switchBlock := basicBlocks[1]
+	if switchBlock.Type != bblock.SWITCH_STATEMENT {
+		t.Fatalf("Basic block nr. 1 should be of type %s, but are of type %s!\n", bblock.SWITCH_STATEMENT, switchBlock.Type)
+	}
+
+	successors := switchBlock.GetSuccessorBlocks()
+	if len(successors) != len(correctCaseBlocks) {
+		t.Fatalf("Number of successors to the switch should be %d, but are %d!\n", len(correctCaseBlocks), len(successors))
+	}
+	for index, correctBlock := range correctCaseBlocks {
+		caseBlock := successors[index]
+		if caseBlock.Number != correctBlock.Number || caseBlock.Type != correctBlock.Type ||
+			caseBlock.EndLine != correctBlock.EndLine {
+			t.Errorf("Case block should be %s, and not %s!\n", correctBlock, caseBlock)
+		}
+	}
+
+	//The loop header loops through the body, and leaves the case when the range is exhausted.
+	rangeBlock := successors[0]
+	correctSuccessors := []int{3, 5}
+	rangeSuccessors := rangeBlock.GetSuccessorBlocks()
+	if len(rangeSuccessors) != len(correctSuccessors) {
+		t.Fatalf("Number of successors to %s should be %d, but are %d!\n", rangeBlock, len(correctSuccessors), len(rangeSuccessors))
+	}
+	for index, number := range correctSuccessors {
+		if rangeSuccessors[index].Number != number {
+			t.Errorf("Successor nr. %d to %s should be BLOCK NR.%d, and not %s!\n", index, rangeBlock, number, rangeSuccessors[index])
+		}
+	}
+}
+
+func TestSuccessorCount(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switch.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, basicBlock := range basicBlocks {
+		if count := basicBlock.SuccessorCount(); count != len(basicBlock.GetSuccessorBlocks()) {
+			t.Errorf("Basic block nr. %d should have %d successors, and not %d!\n", basicBlock.Number,
+				len(basicBlock.GetSuccessorBlocks()), count)
+		}
+	}
+	if count := basicBlocks[1].SuccessorCount(); count != 6 {
+		t.Errorf("Switch block should have 6 successors, and not %d!\n", count)
+	}
+}
+
+func TestPredecessorBlocks(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The loop header holds the loop condition, and is the only block leaving the loop.
+	testCases := []struct {
+		number       int
+		predecessors []*bblock.BasicBlock
+	}{
+		{4, []*bblock.BasicBlock{}},                               //Function entry of gcd.
+		{5, []*bblock.BasicBlock{basicBlocks[4], basicBlocks[6]}}, //Loop header, entered from function entry and loop body.
+		{6, []*bblock.BasicBlock{basicBlocks[5]}},                 //Loop body.
+		{7, []*bblock.BasicBlock{basicBlocks[5]}},                 //Return block, entered when the loop condition fails.
+	}
+
+	for _, testCase := range testCases {
+		predecessors := basicBlocks[testCase.number].GetPredecessorBlocks()
+		if !reflect.DeepEqual(predecessors, testCase.predecessors) {
+			t.Errorf("Basic-block nr. %d should have predecessors %v, and not %v!\n", testCase.number,
+				testCase.predecessors, predecessors)
+		}
+	}
+}
+
+func TestSuccessorUIDs(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switch.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Switch block with a default clause is succeeded by every case clause, sorted by line.
+	correctUIDs := []string{"15", "18", "20", "22", "25", "27"}
+	if uids := basicBlocks[1].SuccessorUIDs(); !reflect.DeepEqual(uids, correctUIDs) {
+		t.Errorf("Switch block should have successor UIDs %v, and not %v!\n", correctUIDs, uids)
+	}
+	if uids := basicBlocks[8].SuccessorUIDs(); len(uids) != 0 {
+		t.Errorf("Return block should have no successor UIDs, and not %v!\n", uids)
+	}
+}
+
+func TestUpdateBasicBlock(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Function main returns at its closing brace, function gcd in a return statement.
+	implicitBlock, returnBlock := basicBlocks[3], basicBlocks[7]
+	if !implicitBlock.ImplicitReturn() || returnBlock.ImplicitReturn() {
+		t.Fatalf("Only %s should return at the closing brace!\n", implicitBlock)
+	}
+
+	//The update copies whether the block returns at the closing brace, both ways.
+	updatedBlock := bblock.NewBasicBlock(0, bblock.RETURN_STMT, 0)
+	updatedBlock.UpdateBasicBlock(implicitBlock)
+	if updatedBlock.String() != implicitBlock.String() || !updatedBlock.ImplicitReturn() {
+		t.Errorf("Updated block %s should return at the closing brace as %s!\n", updatedBlock, implicitBlock)
+	}
+	updatedBlock.UpdateBasicBlock(returnBlock)
+	if updatedBlock.String() != returnBlock.String() || updatedBlock.ImplicitReturn() {
+		t.Errorf("Updated block %s should return in a return statement as %s!\n", updatedBlock, returnBlock)
+	}
 }
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+)
+
+// Analyzer finds the basic-blocks of Go source, caching the results of the most recently
+// analyzed sources by content, as when re-analyzing a file being edited on every keystroke.
+// An Analyzer is safe for concurrent use.
+type Analyzer struct {
+	mutex     sync.Mutex
+	cacheSize int                                         //Maximum number of cached sources.
+	entries   map[[sha256.Size]byte]*list.Element         //Cache entries, keyed by SHA-256 of the source.
+	recent    *list.List                                  //Cache entries, most recently used first.
+	parse     func(srcFile []byte) ([]*BasicBlock, error) //Finds the basic-blocks of uncached sources.
+}
+
+// cacheEntry is the cached result of analyzing the source with SHA-256 hash.
+type cacheEntry struct {
+	hash        [sha256.Size]byte
+	basicBlocks []*BasicBlock
+	err         error
+}
+
+// NewAnalyzer returns an Analyzer caching the results of the cacheSize most recently used
+// sources, caching nothing if cacheSize is zero or less.
+func NewAnalyzer(cacheSize int) *Analyzer {
+	return &Analyzer{
+		cacheSize: cacheSize,
+		entries:   map[[sha256.Size]byte]*list.Element{},
+		recent:    list.New(),
+		parse:     GetBasicBlocksFromSourceCode,
+	}
+}
+
+// Blocks returns the basic-blocks of srcFile as GetBasicBlocksFromSourceCode. Sources equal
+// to a cached source are not parsed again, their basic-blocks and parse errors are returned
+// from the cache. The basic-blocks are shared by the calls returning them and must not be
+// modified.
+func (analyzer *Analyzer) Blocks(srcFile []byte) ([]*BasicBlock, error) {
+	hash := sha256.Sum256(srcFile)
+	analyzer.mutex.Lock()
+	if element, ok := analyzer.entries[hash]; ok {
+		analyzer.recent.MoveToFront(element)
+		entry := element.Value.(*cacheEntry)
+		analyzer.mutex.Unlock()
+		return entry.basicBlocks, entry.err
+	}
+	analyzer.mutex.Unlock()
+
+	//Parsed unlocked, so other sources are analyzed meanwhile.
+	basicBlocks, err := analyzer.parse(srcFile)
+
+	analyzer.mutex.Lock()
+	defer analyzer.mutex.Unlock()
+	if _, ok := analyzer.entries[hash]; ok || analyzer.cacheSize <= 0 {
+		return basicBlocks, err
+	}
+	analyzer.entries[hash] = analyzer.recent.PushFront(&cacheEntry{hash: hash, basicBlocks: basicBlocks, err: err})
+	if analyzer.recent.Len() > analyzer.cacheSize {
+		leastRecent := analyzer.recent.Back()
+		analyzer.recent.Remove(leastRecent)
+		delete(analyzer.entries, leastRecent.Value.(*cacheEntry).hash)
+	}
+	return basicBlocks, err
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+// countingAnalyzer returns an Analyzer caching cacheSize sources, and the number of
+// sources it has parsed.
+func countingAnalyzer(cacheSize int) (*bblock.Analyzer, *int) {
+	analyzer := bblock.NewAnalyzer(cacheSize)
+	parses := 0
+	analyzer.SetParse(func(srcFile []byte) ([]*bblock.BasicBlock, error) {
+		parses++
+		return bblock.GetBasicBlocksFromSourceCode(srcFile)
+	})
+	return analyzer, &parses
+}
+
+func TestAnalyzerCache(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzer, parses := countingAnalyzer(2)
+
+	basicBlocks, err := analyzer.Blocks(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyBasicBlocks(basicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//Identical content, also in a different slice, is not parsed again.
+	cachedBasicBlocks, err := analyzer.Blocks(append([]byte{}, srcFile...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *parses != 1 {
+		t.Errorf("Number of parses should be 1, but are %d!\n", *parses)
+	}
+	if err := verifyBasicBlocks(cachedBasicBlocks, correctBasicBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	//Edited content is parsed.
+	if _, err := analyzer.Blocks(append(srcFile, '\n')); err != nil {
+		t.Fatal(err)
+	}
+	if *parses != 2 {
+		t.Errorf("Number of parses should be 2, but are %d!\n", *parses)
+	}
+
+	//Parse errors are cached too.
+	for i := 0; i < 2; i++ {
+		if _, err := analyzer.Blocks([]byte("package main\n\nfunc main() {\n")); err == nil {
+			t.Error("Analyzing function missing its end should give an error!")
+		}
+	}
+	if *parses != 3 {
+		t.Errorf("Number of parses should be 3, but are %d!\n", *parses)
+	}
+}
+
+func TestAnalyzerCacheEviction(t *testing.T) {
+	sources := [][]byte{
+		[]byte("package main\n\nfunc a() {\n}\n"),
+		[]byte("package main\n\nfunc b() {\n}\n"),
+		[]byte("package main\n\nfunc c() {\n}\n"),
+	}
+	analyzer, parses := countingAnalyzer(2)
+
+	//a and b are cached, using a again makes b the least recently used.
+	for _, index := range []int{0, 1, 0} {
+		if _, err := analyzer.Blocks(sources[index]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *parses != 2 {
+		t.Errorf("Number of parses should be 2, but are %d!\n", *parses)
+	}
+
+	//Caching c evicts b, and not a.
+	for _, index := range []int{2, 0, 1} {
+		if _, err := analyzer.Blocks(sources[index]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *parses != 4 {
+		t.Errorf("Number of parses should be 4, but are %d!\n", *parses)
+	}
+
+	uncachedAnalyzer, parses := countingAnalyzer(0)
+	for i := 0; i < 2; i++ {
+		if _, err := uncachedAnalyzer.Blocks(sources[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *parses != 2 {
+		t.Errorf("Number of parses without cache should be 2, but are %d!\n", *parses)
+	}
+}
+
+func TestAnalyzerConcurrentUse(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_looper.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzer := bblock.NewAnalyzer(1)
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if _, err := analyzer.Blocks(srcFile); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	waitGroup.Wait()
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+// Dominators returns the immediate dominator of each of the blocks reachable from entry,
+// computed by the iterative algorithm of Cooper, Harvey and Kennedy over the predecessors
+// of the blocks. The entry block is its own immediate dominator. Blocks not reachable from
+// entry are left out.
+func Dominators(entry *BasicBlock, blocks []*BasicBlock) map[*BasicBlock]*BasicBlock {
+	inBlocks := make(map[*BasicBlock]bool, len(blocks))
+	for _, block := range blocks {
+		inBlocks[block] = true
+	}
+
+	//Number the blocks in postorder, walking successors from entry.
+	postorder := []*BasicBlock{}
+	postorderNumber := map[*BasicBlock]int{}
+	visited := map[*BasicBlock]bool{}
+	var visit func(block *BasicBlock)
+	visit = func(block *BasicBlock) {
+		visited[block] = true
+		for _, successor := range block.GetSuccessorBlocks() {
+			if inBlocks[successor] && !visited[successor] {
+				visit(successor)
+			}
+		}
+		postorderNumber[block] = len(postorder)
+		postorder = append(postorder, block)
+	}
+	visit(entry)
+
+	intersect := func(a, b *BasicBlock, idom map[*BasicBlock]*BasicBlock) *BasicBlock {
+		for a != b {
+			for postorderNumber[a] < postorderNumber[b] {
+				a = idom[a]
+			}
+			for postorderNumber[b] < postorderNumber[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+
+	idom := map[*BasicBlock]*BasicBlock{entry: entry}
+	for changed := true; changed; {
+		changed = false
+		//Reverse postorder, skipping entry being last in postorder.
+		for index := len(postorder) - 2; index >= 0; index-- {
+			block := postorder[index]
+			var newIdom *BasicBlock
+			for _, predecessor := range block.GetPredecessorBlocks() {
+				if _, ok := idom[predecessor]; !ok {
+					continue //Not yet processed, or not reachable from entry.
+				}
+				if newIdom == nil {
+					newIdom = predecessor
+				} else {
+					newIdom = intersect(predecessor, newIdom, idom)
+				}
+			}
+			if idom[block] != newIdom {
+				idom[block] = newIdom
+				changed = true
+			}
+		}
+	}
+	return idom
+}
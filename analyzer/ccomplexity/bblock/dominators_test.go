@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+func TestDominators(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksForFunction(srcFile, "gcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, loopCondition, loopBody, returnBlock := basicBlocks[0], basicBlocks[1], basicBlocks[2], basicBlocks[3]
+
+	idom := bblock.Dominators(entry, basicBlocks)
+	if len(idom) != len(basicBlocks) {
+		t.Errorf("Number of dominated basic-blocks should be %d, but are %d!\n", len(basicBlocks), len(idom))
+	}
+
+	testCases := []struct {
+		block, idom *bblock.BasicBlock
+	}{
+		{entry, entry}, //Entry dominates itself.
+		{loopCondition, entry},
+		{loopBody, loopCondition},
+		{returnBlock, loopCondition},
+	}
+	for _, testCase := range testCases {
+		if idom[testCase.block] != testCase.idom {
+			t.Errorf("Immediate dominator of %s should be %s, and not %s!\n", testCase.block, testCase.idom,
+				idom[testCase.block])
+		}
+	}
+}
+
+func TestDominatorsIfElse(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_bareblock.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksForFunction(srcFile, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Both branches join in the call after the if statement, dominated by the if condition.
+	idom := bblock.Dominators(basicBlocks[0], basicBlocks)
+	ifCondition, join := basicBlocks[2], basicBlocks[6]
+	if ifCondition.Type != bblock.IF_CONDITION || join.EndLine != 18 {
+		t.Fatalf("Basic-blocks should be the if condition and the call at line 18, and not %s and %s!\n",
+			ifCondition, join)
+	}
+	if idom[join] != ifCondition {
+		t.Errorf("Immediate dominator of %s should be %s, and not %s!\n", join, ifCondition, idom[join])
+	}
+}
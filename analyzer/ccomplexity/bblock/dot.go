@@ -0,0 +1,35 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// WriteDOT writes the basic-blocks and their successor edges to w as a Graphviz
+// (www.graphviz.org) digraph. Nodes are identified by UID and labeled by String,
+// the START and EXIT meta-blocks are drawn as double circles. Blocks are written
+// in the order given, and edges in the order of GetSuccessorBlocks.
+func WriteDOT(w io.Writer, blocks []*BasicBlock) error {
+	var content bytes.Buffer
+	content.WriteString("digraph blocks {\n")
+	for _, block := range blocks {
+		if block.Type == START || block.Type == EXIT {
+			fmt.Fprintf(&content, "\t%q [label=%q, shape=doublecircle];\n", block.UID(), block.String())
+		} else {
+			fmt.Fprintf(&content, "\t%q [label=%q];\n", block.UID(), block.String())
+		}
+	}
+	for _, block := range blocks {
+		for _, successor := range block.GetSuccessorBlocks() {
+			fmt.Fprintf(&content, "\t%q -> %q;\n", block.UID(), successor.UID())
+		}
+	}
+	content.WriteString("}\n")
+
+	_, err := w.Write(content.Bytes())
+	return err
+}
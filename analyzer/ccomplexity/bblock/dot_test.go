@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+func TestWriteDOT(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden, err := ioutil.ReadFile("./testcode/_gcd.dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for run := 0; run < 3; run++ {
+		var dot bytes.Buffer
+		if err := bblock.WriteDOT(&dot, basicBlocks); err != nil {
+			t.Fatal(err)
+		}
+		if dot.String() != string(golden) {
+			t.Fatalf("DOT output should be\n%s\nbut is\n%s\n", golden, dot.String())
+		}
+	}
+}
+
+func TestWriteDOTMetaBlocks(t *testing.T) {
+	start := bblock.NewBasicBlock(-1, bblock.START, 0)
+	function := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
+	exit := bblock.NewBasicBlock(-1, bblock.EXIT, 0)
+	start.AddSuccessorBlock(function)
+	function.AddSuccessorBlock(exit)
+
+	var dot bytes.Buffer
+	if err := bblock.WriteDOT(&dot, []*bblock.BasicBlock{start, function, exit}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"\t\"" + start.UID() + "\" [label=\"START\", shape=doublecircle];\n",
+		"\t\"8\" [label=\"BLOCK NR.0 (FUNCTION_ENTRY) (EndLine: 8)\"];\n",
+		"\t\"" + exit.UID() + "\" [label=\"EXIT\", shape=doublecircle];\n",
+		"\t\"" + start.UID() + "\" -> \"8\";\n",
+		"\t\"8\" -> \"" + exit.UID() + "\";\n",
+	} {
+		if !strings.Contains(dot.String(), line) {
+			t.Errorf("DOT output should contain %q, but is\n%s\n", line, dot.String())
+		}
+	}
+}
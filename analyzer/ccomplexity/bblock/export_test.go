@@ -0,0 +1,9 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+// SetParse replaces the function analyzer uses to find the basic-blocks of uncached sources.
+func (analyzer *Analyzer) SetParse(parse func(srcFile []byte) ([]*BasicBlock, error)) {
+	analyzer.parse = parse
+}
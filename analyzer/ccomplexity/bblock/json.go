@@ -0,0 +1,32 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+import "encoding/json"
+
+// jsonBasicBlock is the JSON form of a basic-block written by ToJSON.
+type jsonBasicBlock struct {
+	Number       int      `json:"number"`
+	Type         string   `json:"type"`
+	FunctionName string   `json:"functionName,omitempty"`
+	EndLine      int      `json:"endLine"`
+	Successors   []string `json:"successors"` //UIDs of the successor blocks.
+}
+
+// ToJSON returns the basic-blocks as a JSON array, in the order given. Each block holds
+// its number, type name, function name for function entries, end line, and the UIDs of
+// its successor blocks in the order of GetSuccessorBlocks.
+func ToJSON(blocks []*BasicBlock) ([]byte, error) {
+	jsonBlocks := make([]jsonBasicBlock, len(blocks))
+	for index, block := range blocks {
+		jsonBlocks[index] = jsonBasicBlock{
+			Number:       block.Number,
+			Type:         block.Type.String(),
+			FunctionName: block.FunctionName,
+			EndLine:      block.EndLine,
+			Successors:   block.SuccessorUIDs(),
+		}
+	}
+	return json.Marshal(jsonBlocks)
+}
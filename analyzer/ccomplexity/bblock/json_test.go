@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+func TestToJSON(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := bblock.ToJSON(basicBlocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var jsonBlocks []map[string]interface{}
+	if err := json.Unmarshal(content, &jsonBlocks); err != nil {
+		t.Fatal(err)
+	}
+	if len(jsonBlocks) != len(basicBlocks) {
+		t.Fatalf("Number of basic-blocks should be %d, but are %d!\n", len(basicBlocks), len(jsonBlocks))
+	}
+
+	testCases := []struct {
+		number       int
+		blockType    bblock.BasicBlockType
+		functionName string
+		endLine      int
+		successors   []interface{}
+	}{
+		{4, bblock.FUNCTION_ENTRY, "gcd", 14, []interface{}{"16"}},
+		{5, bblock.FOR_STATEMENT, "", 16, []interface{}{"19", "20"}}, //Loop body and exit.
+		{6, bblock.FOR_BODY, "", 19, []interface{}{"16"}},            //Back to the loop header.
+		{7, bblock.RETURN_STMT, "", 20, []interface{}{}},
+	}
+
+	for _, testCase := range testCases {
+		jsonBlock := jsonBlocks[testCase.number]
+		if jsonBlock["number"] != float64(testCase.number) {
+			t.Errorf("Basic-block nr. %d should have number %d, but has %v!\n", testCase.number, testCase.number,
+				jsonBlock["number"])
+		}
+		if jsonBlock["type"] != testCase.blockType.String() {
+			t.Errorf("Basic-block nr. %d should have type %s, but has %v!\n", testCase.number, testCase.blockType,
+				jsonBlock["type"])
+		}
+		if name, _ := jsonBlock["functionName"].(string); name != testCase.functionName {
+			t.Errorf("Basic-block nr. %d should have function name %q, but has %q!\n", testCase.number,
+				testCase.functionName, name)
+		}
+		if jsonBlock["endLine"] != float64(testCase.endLine) {
+			t.Errorf("Basic-block nr. %d should end at line %d, but ends at %v!\n", testCase.number, testCase.endLine,
+				jsonBlock["endLine"])
+		}
+		if !reflect.DeepEqual(jsonBlock["successors"], testCase.successors) {
+			t.Errorf("Basic-block nr. %d should have successors %v, but has %v!\n", testCase.number,
+				testCase.successors, jsonBlock["successors"])
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+// Loop is a natural loop, the blocks of a cycle entered only through its header.
+type Loop struct {
+	Header *BasicBlock   //Block dominating the loop, the condition of a for statement.
+	Body   []*BasicBlock //Blocks of the loop including the header, in order of the basic-blocks.
+}
+
+// NaturalLoops returns the natural loops of the functions in blocks, in order of their headers.
+// A back edge is an edge to a successor dominating its source, found with Dominators from
+// each FUNCTION_ENTRY block, and its loop is the header and the blocks reaching the source
+// without passing the header. Back edges to the same header make up one loop.
+func NaturalLoops(blocks []*BasicBlock) []Loop {
+	inLoop := map[*BasicBlock]map[*BasicBlock]bool{}
+	for _, entry := range blocks {
+		if entry.Type != FUNCTION_ENTRY {
+			continue
+		}
+		idom := Dominators(entry, blocks)
+		for source := range idom {
+			for _, header := range source.GetSuccessorBlocks() {
+				if _, ok := idom[header]; !ok || !dominates(header, source, idom) {
+					continue
+				}
+				if inLoop[header] == nil {
+					inLoop[header] = map[*BasicBlock]bool{header: true}
+				}
+				collectLoopBody(source, inLoop[header], idom)
+			}
+		}
+	}
+
+	loops := []Loop{}
+	for _, header := range blocks {
+		if inLoop[header] == nil {
+			continue
+		}
+		loop := Loop{Header: header}
+		for _, block := range blocks {
+			if inLoop[header][block] {
+				loop.Body = append(loop.Body, block)
+			}
+		}
+		loops = append(loops, loop)
+	}
+	return loops
+}
+
+// dominates reports whether block a dominates block b, walking up the immediate dominators of b.
+func dominates(a, b *BasicBlock, idom map[*BasicBlock]*BasicBlock) bool {
+	for {
+		if b == a {
+			return true
+		}
+		if idom[b] == b {
+			return false //Reached entry.
+		}
+		b = idom[b]
+	}
+}
+
+// collectLoopBody adds block and its predecessors, transitively, to body, stopping at blocks
+// already in body, such as the header. Blocks unreachable from entry, missing in idom, are left out.
+func collectLoopBody(block *BasicBlock, body map[*BasicBlock]bool, idom map[*BasicBlock]*BasicBlock) {
+	if _, ok := idom[block]; !ok || body[block] {
+		return
+	}
+	body[block] = true
+	for _, predecessor := range block.GetPredecessorBlocks() {
+		collectLoopBody(predecessor, body, idom)
+	}
+}
+
+// InfiniteLoops returns the headers of the natural loops in blocks never left for the end of the
+// function, in order of the basic-blocks. A loop is left when a RETURN_STMT block, or a block
+// calling panic, is reachable from its header, also when the return or panic is inside the loop.
+func InfiniteLoops(blocks []*BasicBlock) []*BasicBlock {
+	headers := []*BasicBlock{}
+	for _, loop := range NaturalLoops(blocks) {
+		if !reachesExit(loop.Header, map[*BasicBlock]bool{}) {
+			headers = append(headers, loop.Header)
+		}
+	}
+	return headers
+}
+
+// reachesExit reports whether a RETURN_STMT block or a block calling panic is reachable from
+// block through successor edges, not passing the blocks in visited.
+func reachesExit(block *BasicBlock, visited map[*BasicBlock]bool) bool {
+	if visited[block] {
+		return false
+	}
+	visited[block] = true
+	if block.Type == RETURN_STMT || block.panics {
+		return true
+	}
+	for _, successor := range block.GetSuccessorBlocks() {
+		if reachesExit(successor, visited) {
+			return true
+		}
+	}
+	return false
+}
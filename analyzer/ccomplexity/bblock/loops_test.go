@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+// verifyLoops checks that the loops have headers and bodies of the basic-blocks numbered in correctLoops,
+// header first.
+func verifyLoops(t *testing.T, basicBlocks []*bblock.BasicBlock, loops []bblock.Loop, correctLoops [][]int) {
+	if len(loops) != len(correctLoops) {
+		t.Fatalf("Number of loops should be %d, but are %d!\n", len(correctLoops), len(loops))
+	}
+	for index, loop := range loops {
+		correctHeader := basicBlocks[correctLoops[index][0]]
+		if loop.Header != correctHeader {
+			t.Errorf("Header of loop %d should be %s, and not %s!\n", index, correctHeader, loop.Header)
+		}
+		if len(loop.Body) != len(correctLoops[index]) {
+			t.Errorf("Number of blocks in loop %d should be %d, but are %d!\n", index, len(correctLoops[index]),
+				len(loop.Body))
+			continue
+		}
+		for _, number := range correctLoops[index] {
+			found := false
+			for _, block := range loop.Body {
+				found = found || block == basicBlocks[number]
+			}
+			if !found {
+				t.Errorf("Loop %d should contain %s, but does not!\n", index, basicBlocks[number])
+			}
+		}
+	}
+}
+
+func TestNaturalLoops(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksForFunction(srcFile, "gcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The for condition heads the loop around the body.
+	verifyLoops(t, basicBlocks, bblock.NaturalLoops(basicBlocks), [][]int{{1, 2}})
+	if basicBlocks[1].Type != bblock.FOR_STATEMENT {
+		t.Errorf("Loop header should be FOR_STATEMENT, and not %s!\n", basicBlocks[1].Type)
+	}
+}
+
+func TestNestedNaturalLoops(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_nestedfor.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The outer loop contains the inner loop.
+	verifyLoops(t, basicBlocks, bblock.NaturalLoops(basicBlocks), [][]int{{1, 2, 3}, {2, 3}})
+}
+
+func TestInfiniteNaturalLoops(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_infiniteloop.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//The for statement without condition heads the loop, left by the else body breaking out of it. The
+	//unreachable return of spin is left out.
+	verifyLoops(t, basicBlocks, bblock.NaturalLoops(basicBlocks), [][]int{{1, 2, 3, 4}, {9, 10, 11}})
+}
+
+func TestInfiniteLoops(t *testing.T) {
+	testCases := []struct {
+		srcPath string
+		headers []int //Numbers of the headers of loops never left.
+	}{
+		{"./testcode/_infiniteloop.go", []int{9}},
+		//The loops in find and retry are left by return and panic, and the outer loop in poll by its condition.
+		{"./testcode/_loopexits.go", []int{1, 18}},
+	}
+
+	for _, testCase := range testCases {
+		srcFile, err := ioutil.ReadFile(testCase.srcPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		headers := bblock.InfiniteLoops(basicBlocks)
+		if len(headers) != len(testCase.headers) {
+			t.Fatalf("Number of infinite loops in %s should be %d, but are %d!\n", testCase.srcPath,
+				len(testCase.headers), len(headers))
+		}
+		for index, header := range headers {
+			if header != basicBlocks[testCase.headers[index]] {
+				t.Errorf("Infinite loop nr. %d in %s should be headed by %s, and not %s!\n", index, testCase.srcPath,
+					basicBlocks[testCase.headers[index]], header)
+			}
+		}
+	}
+}
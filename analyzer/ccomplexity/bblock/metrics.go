@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+// controlStructureTypes holds the basic-block types starting a control structure.
+var controlStructureTypes = map[BasicBlockType]bool{
+	IF_CONDITION:     true,
+	SWITCH_STATEMENT: true,
+	SELECT_STATEMENT: true,
+	FOR_STATEMENT:    true,
+	RANGE_STATEMENT:  true,
+}
+
+// BlockTypeDiversity returns the number of distinct control-structure block
+// types (if, switch, select, for and range) used in the basic-blocks.
+func BlockTypeDiversity(basicBlocks []*BasicBlock) int {
+	usedTypes := map[BasicBlockType]bool{}
+	for _, basicBlock := range basicBlocks {
+		if controlStructureTypes[basicBlock.Type] {
+			usedTypes[basicBlock.Type] = true
+		}
+	}
+	return len(usedTypes)
+}
+
+// UnreachableBlocks returns the basic-blocks not reachable through successor edges from
+// the FUNCTION_ENTRY block of their function, in the order given. Blocks following an
+// unconditional return, before the next function entry, are such dead code.
+func UnreachableBlocks(blocks []*BasicBlock) []*BasicBlock {
+	reached := map[*BasicBlock]bool{}
+	var visit func(block *BasicBlock)
+	visit = func(block *BasicBlock) {
+		if reached[block] {
+			return
+		}
+		reached[block] = true
+		for _, successor := range block.GetSuccessorBlocks() {
+			visit(successor)
+		}
+	}
+	for _, block := range blocks {
+		if block.Type == FUNCTION_ENTRY {
+			visit(block)
+		}
+	}
+
+	unreachable := []*BasicBlock{}
+	for _, block := range blocks {
+		if !reached[block] {
+			unreachable = append(unreachable, block)
+		}
+	}
+	return unreachable
+}
+
+// ComplexityByDepth returns the cyclomatic complexity of the functions in blocks keyed by the
+// nesting depth it comes from. A block branching to n successors adds n-1 at its Depth, and
+// each function adds 1 at depth 0, so the values sum to the number of decisions plus one per
+// function. Depths without complexity are left out.
+func ComplexityByDepth(blocks []*BasicBlock) map[int]int {
+	complexities := map[int]int{}
+	for _, block := range blocks {
+		if block.Type == FUNCTION_ENTRY {
+			complexities[0]++
+		}
+		if successors := len(block.GetSuccessorBlocks()); successors > 1 {
+			complexities[block.Depth] += successors - 1
+		}
+	}
+	return complexities
+}
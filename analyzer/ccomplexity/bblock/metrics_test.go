@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+func TestBlockTypeDiversity(t *testing.T) {
+	testCases := []struct {
+		srcPath   string
+		diversity int
+	}{
+		{"./testcode/_simple.go", 0},
+		{"./testcode/_gcd.go", 1},
+		{"./testcode/_simplelooperswitch.go", 2},
+		{"./testcode/_diversity.go", 4},
+	}
+
+	for _, testCase := range testCases {
+		srcFile, err := ioutil.ReadFile(testCase.srcPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diversity := bblock.BlockTypeDiversity(basicBlocks); diversity != testCase.diversity {
+			t.Errorf("Block type diversity in %s should be %d, and not %d!\n", testCase.srcPath, testCase.diversity, diversity)
+		}
+	}
+}
+
+func TestUnreachableBlocks(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_deadcode.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Only the call following the return statement in half is unreachable.
+	unreachable := bblock.UnreachableBlocks(basicBlocks)
+	if len(unreachable) != 1 {
+		t.Fatalf("Number of unreachable basic-blocks should be %d, but are %d!\n", 1, len(unreachable))
+	}
+	if unreachable[0] != basicBlocks[6] || unreachable[0].EndLine != 15 {
+		t.Errorf("Unreachable basic-block should be nr. 6 at line 15, and not %v!\n", unreachable[0])
+	}
+
+	srcFile, err = ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err = bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unreachable := bblock.UnreachableBlocks(basicBlocks); len(unreachable) != 0 {
+		t.Errorf("Number of unreachable basic-blocks should be %d, but are %d!\n", 0, len(unreachable))
+	}
+}
+
+func TestComplexityByDepth(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_depths.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctDepths := map[int]int{
+		10: 0, //If in the function body.
+		11: 1, //Return in the if body.
+		13: 0, //Outer loop.
+		14: 1, //Inner loop.
+		15: 2, //If in the inner loop.
+		17: 3, //Body of the if in the inner loop.
+		19: 1, //Switch in the outer loop.
+		21: 2, //Case clause of the switch.
+		26: 0, //Return in the function body.
+	}
+	for _, basicBlock := range basicBlocks {
+		if depth, ok := correctDepths[basicBlock.EndLine]; ok && basicBlock.Depth != depth {
+			t.Errorf("Depth of basic-block at line %d should be %d, and not %d!\n", basicBlock.EndLine, depth, basicBlock.Depth)
+		}
+	}
+
+	//Two functions, the if and the outer loop, also branching to the switch, at depth 0. The inner loop
+	//and the switch with two cases at depth 1, and the if in the inner loop at depth 2.
+	correctComplexities := map[int]int{0: 5, 1: 3, 2: 1}
+	if complexities := bblock.ComplexityByDepth(basicBlocks); !reflect.DeepEqual(complexities, correctComplexities) {
+		t.Errorf("Complexity by depth should be %v, and not %v!\n", correctComplexities, complexities)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AnalyzePackage returns the basic-blocks of each function in the Go source files in dir,
+// keyed by file and function name as in main.go:main, and numbered from zero in each
+// function as by GetFunctionBasicBlocksFromSourceCode. Files are read as bytes, so files
+// not compiled by the go tool, as _-prefixed test code, are analyzed too. Subdirectories
+// are not searched. A file failing to parse fails the analysis, the error naming the file.
+func AnalyzePackage(dir string) (map[string][]*BasicBlock, error) {
+	return analyzePackage(dir, nil)
+}
+
+// AnalyzePackageSkippingTests returns the basic-blocks of the Go source files in dir as
+// AnalyzePackage, leaving out _test.go files.
+func AnalyzePackageSkippingTests(dir string) (map[string][]*BasicBlock, error) {
+	return analyzePackage(dir, func(fileInfo os.FileInfo) bool {
+		return !strings.HasSuffix(fileInfo.Name(), "_test.go")
+	})
+}
+
+// analyzePackage returns the basic-blocks of the Go source files in dir passing filter,
+// or of all Go source files if filter is nil.
+func analyzePackage(dir string, filter func(os.FileInfo) bool) (map[string][]*BasicBlock, error) {
+	//Only the package clauses are parsed to find the files, the files are parsed when analyzed.
+	packages, err := parser.ParseDir(token.NewFileSet(), dir, filter, parser.PackageClauseOnly)
+	if err != nil {
+		return nil, err
+	}
+	var srcPaths []string
+	for _, pkg := range packages {
+		for srcPath := range pkg.Files {
+			srcPaths = append(srcPaths, srcPath)
+		}
+	}
+	sort.Strings(srcPaths)
+
+	functions := map[string][]*BasicBlock{}
+	for _, srcPath := range srcPaths {
+		srcFile, err := ioutil.ReadFile(srcPath)
+		if err != nil {
+			return nil, err
+		}
+		fileFunctions, err := GetFunctionBasicBlocksFromSourceCode(srcFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", srcPath, err)
+		}
+		for functionName, basicBlocks := range fileFunctions {
+			functions[filepath.Base(srcPath)+":"+functionName] = basicBlocks
+		}
+	}
+	return functions, nil
+}
+
+// AnalyzeFiles returns the basic-blocks of each Go source file in paths as
+// GetBasicBlocksFromSourceCode, keyed by path. The files are analyzed concurrently by
+// workers goroutines, at least one, each parsing with its own file set. Files failing to
+// be read, parsed or analyzed are left out, and their errors returned joined in path order.
+func AnalyzeFiles(paths []string, workers int) (map[string][]*BasicBlock, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	srcPaths := make(chan string)
+	files := map[string][]*BasicBlock{}
+	fileErrs := map[string]error{}
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for srcPath := range srcPaths {
+				basicBlocks, err := analyzeFile(srcPath)
+				mutex.Lock()
+				if err != nil {
+					fileErrs[srcPath] = err
+				} else {
+					files[srcPath] = basicBlocks
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for _, srcPath := range paths {
+		srcPaths <- srcPath
+	}
+	close(srcPaths)
+	waitGroup.Wait()
+
+	if len(fileErrs) == 0 {
+		return files, nil
+	}
+	var errPaths []string
+	for srcPath := range fileErrs {
+		errPaths = append(errPaths, srcPath)
+	}
+	sort.Strings(errPaths)
+	var errs []error
+	for _, srcPath := range errPaths {
+		errs = append(errs, fileErrs[srcPath])
+	}
+	return files, errors.Join(errs...)
+}
+
+// analyzeFile returns the basic-blocks of the Go source file srcPath, the error naming the file.
+// A panic while analyzing the file is returned as error, leaving the other files to the worker.
+func analyzeFile(srcPath string) (basicBlocks []*BasicBlock, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			basicBlocks, err = nil, fmt.Errorf("%s: %v", srcPath, r)
+		}
+	}()
+
+	srcFile, err := ioutil.ReadFile(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	basicBlocks, err = GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", srcPath, err)
+	}
+	return basicBlocks, nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+func TestAnalyzePackage(t *testing.T) {
+	functions, err := bblock.AnalyzePackage("./testcode")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		function    string
+		basicBlocks int
+	}{
+		{"_gcd.go:gcd", 4},
+		{"_gcd.go:main", 4},
+		{"_looper.go:main", 5},
+	}
+	for _, testCase := range testCases {
+		if len(functions[testCase.function]) != testCase.basicBlocks {
+			t.Errorf("Number of basic-blocks in %s should be %d, but are %d!\n", testCase.function,
+				testCase.basicBlocks, len(functions[testCase.function]))
+		}
+	}
+	for function, basicBlocks := range functions {
+		if basicBlocks[0].Type != bblock.FUNCTION_ENTRY || basicBlocks[0].Number != 0 {
+			t.Errorf("Basic-blocks of %s should start with FUNCTION_ENTRY nr. 0, and not %s!\n", function, basicBlocks[0])
+		}
+	}
+}
+
+func TestAnalyzePackageWithoutBodies(t *testing.T) {
+	//Functions declared without body are left out, as are files without functions.
+	functions, err := bblock.AnalyzePackage("./testcode/linkname")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctFunctions := map[string]int{
+		"_clock.go:since": 6,
+		"_clock.go:check": 4,
+	}
+	if len(functions) != len(correctFunctions) {
+		t.Fatalf("Number of functions should be %d, but are %d!\n", len(correctFunctions), len(functions))
+	}
+	for function, basicBlocks := range correctFunctions {
+		if len(functions[function]) != basicBlocks {
+			t.Errorf("Number of basic-blocks in %s should be %d, but are %d!\n", function, basicBlocks,
+				len(functions[function]))
+		}
+	}
+}
+
+func TestAnalyzePackageSkippingTests(t *testing.T) {
+	functions, err := bblock.AnalyzePackage(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := functions["package_test.go:TestAnalyzePackage"]; !ok {
+		t.Error("Test function TestAnalyzePackage should be analyzed!")
+	}
+
+	functions, err = bblock.AnalyzePackageSkippingTests(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := functions["package.go:AnalyzePackage"]; !ok {
+		t.Error("Function AnalyzePackage should be analyzed!")
+	}
+	for function := range functions {
+		if strings.Contains(function, "_test.go:") {
+			t.Errorf("Function %s in test file should be skipped!\n", function)
+		}
+	}
+}
+
+func TestAnalyzePackageParseError(t *testing.T) {
+	_, err := bblock.AnalyzePackage("./testcode/unterminated")
+	if err == nil {
+		t.Fatal("Analyzing package with unterminated comment should fail!")
+	}
+	if !strings.Contains(err.Error(), "_unterminatedcomment.go") {
+		t.Errorf("Error should name the file _unterminatedcomment.go, and not be %q!\n", err)
+	}
+	var unterminatedErr *bblock.UnterminatedError
+	if !errors.As(err, &unterminatedErr) {
+		t.Errorf("Error should wrap an UnterminatedError, and not be %q!\n", err)
+	}
+}
+
+func TestAnalyzeFiles(t *testing.T) {
+	paths := []string{
+		"./testcode/_gcd.go",
+		"./testcode/_looper.go",
+		"./testcode/_switch.go",
+		"./testcode/_closures.go",
+		"./testcode/_receivers.go",
+		"./testcode/_select.go",
+		"./testcode/_bodyless.go",
+	}
+
+	//The merged map must be the same every run, and as when analyzing the files one by one.
+	for run := 0; run < 5; run++ {
+		files, err := bblock.AnalyzeFiles(paths, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) != len(paths) {
+			t.Fatalf("Number of files should be %d, but are %d!\n", len(paths), len(files))
+		}
+		for _, path := range paths {
+			srcFile, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			correctBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := verifyBasicBlocks(files[path], correctBasicBlocks); err != nil {
+				t.Fatalf("%s: %s", path, err)
+			}
+		}
+	}
+}
+
+func TestAnalyzeFilesErrors(t *testing.T) {
+	paths := []string{
+		"./testcode/unterminated/_unterminatedstring.go",
+		"./testcode/_gcd.go",
+		"./testcode/_missing.go",
+		"./testcode/unterminated/_unterminatedcomment.go",
+	}
+	files, err := bblock.AnalyzeFiles(paths, 4)
+	if err == nil {
+		t.Fatal("Analyzing unterminated and missing files should fail!")
+	}
+	if len(files) != 1 || files["./testcode/_gcd.go"] == nil {
+		t.Errorf("Only _gcd.go should be analyzed, and not %d files!\n", len(files))
+	}
+
+	//Errors are joined in path order.
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "_missing.go") ||
+		!strings.Contains(lines[1], "_unterminatedcomment.go") || !strings.Contains(lines[2], "_unterminatedstring.go") {
+		t.Errorf("Errors should name _missing.go, _unterminatedcomment.go and _unterminatedstring.go, and not be %q!\n", err)
+	}
+	var unterminatedErr *bblock.UnterminatedError
+	if !errors.As(err, &unterminatedErr) {
+		t.Errorf("Error should wrap an UnterminatedError, and not be %q!\n", err)
+	}
+}
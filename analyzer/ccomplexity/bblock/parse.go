@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+)
+
+// unterminatedConstructs maps the scanner errors of constructs missing their end to the construct.
+var unterminatedConstructs = map[string]string{
+	"raw string literal not terminated": "raw string literal",
+	"string literal not terminated":     "string literal",
+	"rune literal not terminated":       "rune literal",
+	"comment not terminated":            "comment",
+}
+
+// UnterminatedError is the error parsing Go source with a string literal, rune literal or
+// comment missing its end, typical of source being edited.
+type UnterminatedError struct {
+	Construct string //Construct missing its end, as "raw string literal" or "comment".
+	Filename  string //Name of the source file, empty if unknown.
+	Line      int    //Line the construct starts on.
+	Column    int    //Column the construct starts at.
+}
+
+func (err *UnterminatedError) Error() string {
+	if err.Filename != "" {
+		return fmt.Sprintf("%s:%d:%d: %s not terminated", err.Filename, err.Line, err.Column, err.Construct)
+	}
+	return fmt.Sprintf("%d:%d: %s not terminated", err.Line, err.Column, err.Construct)
+}
+
+// Unterminated returns an UnterminatedError for the first construct not terminated among the
+// errors of parsing Go source, or err unchanged if there is none. A construct not terminated
+// swallows the rest of the file, so the parse errors following it, as the unexpected end of
+// file, are left out.
+func Unterminated(err error) error {
+	errorList, ok := err.(scanner.ErrorList)
+	if !ok {
+		return err
+	}
+	for _, parseErr := range errorList {
+		if construct, ok := unterminatedConstructs[parseErr.Msg]; ok {
+			return &UnterminatedError{Construct: construct, Filename: parseErr.Pos.Filename, Line: parseErr.Pos.Line,
+				Column: parseErr.Pos.Column}
+		}
+	}
+	return err
+}
+
+// parseSource parses srcFile named filename with mode, reporting constructs not terminated as
+// UnterminatedError. Parse errors are reported at positions in filename, which may be empty.
+func parseSource(fileSet *token.FileSet, filename string, srcFile []byte, mode parser.Mode) (*ast.File, error) {
+	file, err := parser.ParseFile(fileSet, filename, srcFile, mode)
+	if err != nil {
+		return nil, Unterminated(err)
+	}
+	return file, nil
+}
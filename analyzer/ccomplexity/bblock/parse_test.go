@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package bblock_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+func TestUnterminatedError(t *testing.T) {
+	testCases := []struct {
+		srcPath   string
+		construct string
+		line      int
+		column    int
+	}{
+		{"./testcode/unterminated/_unterminatedstring.go", "raw string literal", 9, 11},
+		{"./testcode/unterminated/_unterminatedcomment.go", "comment", 10, 2},
+	}
+
+	for _, testCase := range testCases {
+		srcFile, err := ioutil.ReadFile(testCase.srcPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = bblock.GetBasicBlocksFromSourceCode(srcFile)
+		unterminatedErr, ok := err.(*bblock.UnterminatedError)
+		if !ok {
+			t.Errorf("Error parsing %s should be UnterminatedError, and not %v!\n", testCase.srcPath, err)
+			continue
+		}
+		if unterminatedErr.Construct != testCase.construct || unterminatedErr.Line != testCase.line ||
+			unterminatedErr.Column != testCase.column {
+			t.Errorf("Error parsing %s should be %s not terminated at %d:%d, and not %s!\n", testCase.srcPath,
+				testCase.construct, testCase.line, testCase.column, unterminatedErr)
+		}
+	}
+
+	//Constructs not terminated are reported in the named file.
+	_, err := bblock.GetBasicBlocksFromNamedReader("edited.go", strings.NewReader("package main\n\n/* main\n"))
+	if unterminatedErr, ok := err.(*bblock.UnterminatedError); !ok || unterminatedErr.Filename != "edited.go" {
+		t.Errorf("Error parsing edited.go should be UnterminatedError in edited.go, and not %v!\n", err)
+	} else if unterminatedErr.Error() != "edited.go:3:1: comment not terminated" {
+		t.Errorf("Error parsing edited.go should be reported at edited.go:3:1, and not as %s!\n", unterminatedErr)
+	}
+
+	//Other parse errors are left unchanged.
+	if _, err := bblock.GetBasicBlocksFromSourceCode([]byte("package main\n\nfunc main() {\n")); err == nil {
+		t.Error("Parsing source missing a brace should fail!")
+	} else if _, ok := err.(*bblock.UnterminatedError); ok {
+		t.Errorf("Error parsing source missing a brace should not be UnterminatedError, but is %s!\n", err)
+	}
+}
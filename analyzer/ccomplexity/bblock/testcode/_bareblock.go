@@ -0,0 +1,19 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Start")
+	{
+		count := 3
+		if count > 2 {
+			fmt.Println("Large")
+		} else {
+			fmt.Println("Small")
+		}
+	}
+	fmt.Println("Done")
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	// BB #0 ending.
+	x := 2
+	if x > 1 { // BB #1 ending.
+		x++
+	} // BB #2 ending.
+
+	if x > 2 { // BB #3 ending.
+		fmt.Println(x) // BB #4 ending.
+	}
+	fmt.Println("Done") // BB #5 ending.
+} // BB #6 ending.
+
+func abs(x int) int {
+	// BB #7 ending.
+	if x < 0 { // BB #8 ending.
+		return -x // BB #9 ending.
+	}
+	return x // BB #10 ending.
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() { // BB #0 ending.
+	// Switch on a number.
+	number := 3
+
+	switch /* on number */ number { // BB #1 ending.
+
+	case 0:
+		fmt.Println("0") // BB #2 ending.
+	case 1:
+		fmt.Println("1")
+		fmt.Println("1.a") // BB #3 ending.
+	case 2:
+		fmt.Println("2") // BB #4 ending.
+	case 3:
+		fmt.Println("3") // BB #5 ending.
+	case 4:
+		fmt.Println("4")
+		return // BB #6 ending.
+	default:
+		fmt.Printf("No match, number is %d!\n", number) // BB #7 ending.
+	}
+} // BB #8 ending.
@@ -0,0 +1,23 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import (
+	"fmt"
+	_ "unsafe"
+)
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+func elapsed(start int64) int64 {
+	if now := nanotime(); now > start {
+		return now - start
+	}
+	return 0
+}
+
+func main() {
+	fmt.Println(elapsed(nanotime()))
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	for i := 0; i < 10; i++ {
+		switch {
+		case i == 3:
+			fmt.Println("three")
+			break
+		default:
+			fmt.Println(i)
+		}
+		if i > 5 {
+			continue
+		} else {
+			fmt.Println("small")
+		}
+		fmt.Println("after")
+	}
+	fmt.Println("done")
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+var handlers = map[string]func(int) int{}
+
+func register(name string, handler func(int) int) {
+	handlers[name] = handler
+}
+
+func main() {
+	register("abs", func(x int) int {
+		if x < 0 {
+			x = -x
+		} else {
+			x = x + 0
+		}
+		return x
+	})
+	fmt.Println(handlers["abs"](-2))
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Start")
+	count := work(0)
+	for i := 0; i < 3; i++ {
+		count = work(count)
+		fmt.Println(count)
+	}
+	(fmt.Println)("Done")
+}
+
+func work(count int) int {
+	return count + 1
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func count(kind int) int { // BB #0 ending.
+	total := 0
+	switch kind { // BB #1 ending.
+	case 0:
+		for i := 0; i < 10; i++ { // BB #2 ending.
+			total += i
+		} // BB #3 ending.
+	case 1:
+		if total == 0 { // BB #4 ending.
+			total = 1
+		} // BB #5 ending.
+	default:
+		total = -1 // BB #6 ending.
+	}
+	return total // BB #7 ending.
+}
+
+func main() { // BB #8 ending.
+	fmt.Println(count(0)) // BB #9 ending.
+} // BB #10 ending.
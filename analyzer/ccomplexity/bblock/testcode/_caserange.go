@@ -0,0 +1,24 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func total(kind int, xs []int) int {
+	// BB #0 ending.
+	switch kind { // BB #1 ending.
+	case 0:
+		for _, x := range xs { // BB #2 ending.
+			kind += x
+		} // BB #3 ending.
+		kind++
+	default:
+		kind = -1 // BB #4 ending.
+	}
+	return kind // BB #5 ending.
+}
+
+func main() {
+	fmt.Println(total(0, []int{1, 2, 3}))
+}
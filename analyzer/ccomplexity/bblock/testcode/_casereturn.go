@@ -0,0 +1,19 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func describe(number int) string {
+	switch number {
+	case 0:
+		fmt.Println("Zero")
+		return "zero"
+	case 1:
+		message := "one"
+		fmt.Println(message)
+		return message
+	}
+	return "many"
+}
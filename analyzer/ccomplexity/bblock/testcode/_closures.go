@@ -0,0 +1,25 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	square := func(x int) int {
+		return x * x
+	}
+	done := make(chan bool)
+	go func() {
+		sum := func(n int) int {
+			total := 0
+			for i := 0; i < n; i++ {
+				total += square(i)
+			}
+			return total
+		}
+		fmt.Println(sum(3))
+		done <- true
+	}()
+	<-done
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(half(4))
+}
+
+func half(n int) int {
+	fmt.Println("Halving")
+	return n / 2
+	fmt.Println("Never printed")
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func process(n int) int {
+	// BB #0 ending.
+	defer fmt.Println("first") // BB #1 ending.
+	switch {                   // BB #2 ending.
+	case n < 0:
+		return -1 // BB #3 ending.
+	default:
+		n = n * 2 // BB #4 ending.
+	}
+	defer fmt.Println("second") // BB #5 ending.
+	fmt.Println(n)              // BB #6 ending.
+	return n                    // BB #7 ending.
+}
+
+func main() {
+	// BB #8 ending.
+	fmt.Println(process(2)) // BB #9 ending.
+} // BB #10 ending.
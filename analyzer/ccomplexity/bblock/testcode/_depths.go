@@ -0,0 +1,31 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func classify(grid [][]int) int {
+	count := 0
+	if len(grid) == 0 {
+		return 0
+	}
+	for i := 0; i < len(grid); i++ {
+		for j := 0; j < len(grid[i]); j++ {
+			if grid[i][j] > 0 {
+				count++
+			}
+		}
+		switch len(grid[i]) {
+		case 0:
+			fmt.Println("empty row")
+		case 1:
+			fmt.Println("single cell")
+		}
+	}
+	return count
+}
+
+func main() {
+	fmt.Println(classify([][]int{{1, 0}, {}}))
+}
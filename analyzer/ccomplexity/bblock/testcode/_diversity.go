@@ -0,0 +1,33 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	numbers := make(chan int)
+	quit := make(chan bool)
+
+	for i := 0; i < 10; i++ {
+		if i%2 == 0 {
+			fmt.Println("Even")
+		} else {
+			fmt.Println("Odd")
+		}
+
+		switch i {
+		case 0:
+			fmt.Println("Zero")
+		default:
+			fmt.Println(i)
+		}
+
+		select {
+		case n := <-numbers:
+			fmt.Println(n)
+		case <-quit:
+			return
+		}
+	}
+}
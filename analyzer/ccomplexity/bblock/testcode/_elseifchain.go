@@ -0,0 +1,21 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	// BB #0 ending.
+	x := 2
+	if x > 2 { // BB #1 ending.
+		fmt.Println("Greater") // BB #2 ending.
+	} else if x < 2 { // BB #3 ending.
+		fmt.Println("Less") // BB #4 ending.
+	} else if x == 0 { // BB #5 ending.
+		x++
+	} else { // BB #6 ending.
+		fmt.Println("Equal")
+	} // BB #7 ending.
+	fmt.Println("Done") // BB #8 ending.
+} // BB #9 ending.
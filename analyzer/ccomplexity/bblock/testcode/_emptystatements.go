@@ -0,0 +1,24 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	count := 0;;
+	;
+	for count < 3 {
+		;
+		count++;
+	};
+	if count == 3 {
+		;
+	} else {
+		fmt.Println(count);
+	}
+	;
+	{
+	}
+	fmt.Println("Done");
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	describe(1)
+	describe(4)
+}
+
+func describe(n int) {
+	switch n {
+	case 1:
+		fmt.Println("One")
+		fallthrough
+	default:
+		fmt.Println("Number")
+	}
+
+	switch n {
+	case 2:
+		fmt.Println("Two")
+		fallthrough
+	default:
+		fmt.Println("Even")
+	case 3:
+		fmt.Println("Three")
+	}
+}
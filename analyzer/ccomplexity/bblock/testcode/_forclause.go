@@ -0,0 +1,21 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func reverse(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func main() {
+	s := []int{1, 2, 3, 4}
+	for i, j := 0,
+		len(s)-1; i < j; i, j = i+1, j-1 {
+		fmt.Println(s[i], s[j])
+	}
+	reverse(s)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "errors"
+
+func call(try int) error {
+	// BB #0 ending.
+	return errors.New("failed") // BB #1 ending.
+}
+
+func fetch(attempts int) error {
+	// BB #2 ending.
+	tries := 0
+retry: // BB #3 ending.
+	tries++
+	err := call(tries)
+	if err != nil && tries < attempts { // BB #4 ending.
+		goto retry // BB #5 ending.
+	} else { // BB #6 ending.
+		tries = 0
+	} // BB #7 ending.
+	return err // BB #8 ending.
+}
+
+func countdown(n int) int {
+	// BB #9 ending.
+loop: for n > 0 { // BB #10 ending.
+		n--
+		if n%2 == 0 { // BB #11 ending.
+			goto loop // BB #12 ending.
+		} else { // BB #13 ending.
+			n--
+		} // BB #14 ending.
+	}
+	return n // BB #15 ending.
+}
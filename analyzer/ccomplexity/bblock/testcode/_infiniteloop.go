@@ -0,0 +1,31 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	// BB #0 ending.
+	count := 0
+	for {
+		// BB #1 ending.
+		count++
+		if count < 10 {
+			// BB #2 ending.
+			fmt.Println(count)
+		} else {
+			// BB #3 ending.
+			break
+		} // BB #4 ending.
+	}
+	fmt.Println("Done") // BB #5 ending.
+}
+
+func spin() {
+	// BB #6 ending.
+	for {
+		// BB #7 ending.
+		fmt.Println("Spinning")
+	} // BB #8 ending.
+} // BB #9 ending.
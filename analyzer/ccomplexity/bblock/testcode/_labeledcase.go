@@ -0,0 +1,39 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func wait(done chan bool, ticks chan int) int {
+	count := 0
+loop:
+	for {
+		select {
+		case <-done:
+			break loop
+		case <-ticks:
+			count++
+		}
+	}
+	return count
+}
+
+func skip(values []int) int {
+	sum := 0
+outer:
+	for i := 0; i < len(values); i++ {
+		switch {
+		case values[i] < 0:
+			continue outer
+		case values[i] == 0:
+			break outer
+		}
+		sum += values[i]
+	}
+	return sum
+}
+
+func main() {
+	fmt.Println(wait(nil, nil), skip([]int{1, -1, 2, 0, 3}))
+}
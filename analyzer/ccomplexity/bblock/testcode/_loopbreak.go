@@ -0,0 +1,21 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func count(c bool) int {
+	x := 0
+	for {
+		if c {
+			break
+		}
+		x++
+	}
+	return x
+}
+
+func main() {
+	fmt.Println(count(true))
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func spin() {
+	for {
+		fmt.Println("Spinning")
+	}
+}
+
+func find(numbers []int, target int) int {
+	index := 0
+	for {
+		if numbers[index] == target {
+			return index
+		}
+		index++
+	}
+}
+
+func retry(attempt func() error) {
+	for {
+		if err := attempt(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func poll(ready func() bool) {
+	for !ready() {
+		for {
+			fmt.Println("Waiting")
+		}
+	}
+}
+
+func main() {
+	fmt.Println(find([]int{1, 2}, 2))
+	retry(func() error { return nil })
+	poll(func() bool { return true })
+	spin()
+}
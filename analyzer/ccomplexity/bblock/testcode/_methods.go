@@ -0,0 +1,34 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+type square struct {
+	side int
+}
+
+type shape struct {
+	kind string
+	size int
+}
+
+func (s square) Area() int {
+	return s.side * s.side
+}
+
+func (s *shape) Area() int {
+	switch s.kind {
+	case "square":
+		return s.size * s.size
+	case "circle":
+		return 3 * s.size * s.size
+	}
+	return 0
+}
+
+func main() {
+	fmt.Println(square{side: 2}.Area())
+	fmt.Println((&shape{kind: "square", size: 3}).Area())
+}
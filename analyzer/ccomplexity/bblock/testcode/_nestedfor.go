@@ -0,0 +1,15 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+func main() {
+	// BB #0 ending.
+	count := 0
+	for i := 0; i < 3; i++ { // BB #1 ending.
+		for j := 0; j < 3; j++ { // BB #2 ending.
+			count++
+		} // BB #3 ending.
+	}
+	println(count) // BB #4 ending.
+} // BB #5 ending.
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+type celsius float64
+
+type point struct {
+	x, y int
+}
+
+func (c celsius) String() string {
+	if c < 0 {
+		return "freezing"
+	}
+	return fmt.Sprintf("%.1f C", float64(c))
+}
+
+func (p *point) String() string {
+	format := func() string {
+		return "(%d, %d)"
+	}
+	return fmt.Sprintf(format(), p.x, p.y)
+}
+
+func (*point) Reset() {
+}
+
+func String() string {
+	return "function"
+}
+
+func main() {
+	fmt.Println(celsius(-1).String(), (&point{1, 2}).String(), String())
+}
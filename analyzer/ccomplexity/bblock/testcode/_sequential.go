@@ -0,0 +1,17 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	x := 1
+	fmt.Println(x)
+	if x > 0 {
+		x++
+	} else {
+		x--
+	}
+	fmt.Println(x)
+}
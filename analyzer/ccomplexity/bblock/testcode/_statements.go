@@ -0,0 +1,21 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	a := 1
+	b := 2
+	for i := 0; i < 3; i++ {
+		a += i
+		b -= i
+	}
+	if a > b {
+		fmt.Println(a)
+	} else {
+		fmt.Println(b)
+	}
+	fmt.Println("Done")
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+type operation struct {
+	name string
+	fn   func(int) int
+}
+
+func main() {
+	// BB #0 ending.
+	abs := operation{
+		name: "abs",
+		fn: func(x int) int {
+			// BB #2 ending.
+			if x < 0 {
+				// BB #3 ending.
+				x = -x
+			} else {
+				// BB #4 ending.
+				x = x + 0
+			} // BB #5 ending.
+			return x // BB #6 ending.
+		},
+	}
+	fmt.Println(abs.fn(-2))
+} // BB #1 ending.
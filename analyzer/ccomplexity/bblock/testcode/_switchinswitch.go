@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func classify(kind, size int) string {
+	label := ""
+	switch kind {
+	case 0:
+		switch size {
+		case 0:
+			label = "empty"
+		case 1:
+			label = "single"
+		}
+	case 1:
+		label = "one"
+	}
+	return label
+}
+
+func describe(kind, size int) string {
+	switch kind {
+	case 0:
+		switch size {
+		case 0:
+			return "empty"
+		case 1:
+			return "single"
+		}
+		return "many"
+	case 1:
+		return "one"
+	}
+	return "other"
+}
+
+func main() {
+	fmt.Println(classify(0, 1), describe(0, 1))
+}
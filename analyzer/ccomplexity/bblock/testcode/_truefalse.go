@@ -0,0 +1,19 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	// BB #0 ending.
+	number := 3
+
+	if number%2 == 0 {
+		// BB #1 ending.
+		fmt.Println("Even")
+	} else {
+		// BB #2 ending.
+		fmt.Println("Odd")
+	} // BB #3 ending.
+} // BB #4 ending.
@@ -0,0 +1,24 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func describe(x interface{}) string {
+	// BB #0 ending.
+	switch v := x.(type) { // BB #1 ending.
+	case int:
+		v = v * 2
+		return fmt.Sprintf("int %d", v) // BB #2 ending.
+	case string:
+		fmt.Println(v) // BB #3 ending.
+	case []int:
+		for _, n := range v { // BB #4 ending.
+			fmt.Println(n)
+		}
+	default:
+		fmt.Printf("%v\n", v) // BB #5 ending.
+	}
+	return "" // BB #6 ending.
+}
@@ -0,0 +1,6 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package linkname declares functions implemented in the runtime, without body.
+package linkname
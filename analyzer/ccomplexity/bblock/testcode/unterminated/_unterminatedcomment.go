@@ -0,0 +1,13 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Commenting")
+	/* The rest of the function is
+	being edited.
+	fmt.Println("Done")
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package cfgraph
+
+import (
+	"bytes"
+	"errors"
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/graph"
+	"go/ast"
+	"go/format"
+	"go/token"
+)
+
+// stmtLine is the line of the appended statement in the source built by AppendStmt.
+const stmtLine = 4
+
+// AppendStmt extends the function in cfg with stmt, appended at the end of the function
+// body, without building the graph of the whole function again. The basic-blocks of stmt
+// replace the block returning at the closing brace, are placed on the lines following the
+// body and numbered from the replaced block, as they would be in a full rebuild. Positions
+// and comments in stmt are ignored, the statement is formatted as by gofmt. Returns an error
+// if the function ends in a return statement, as stmt would never run.
+func (cfg *ControlFlowGraph) AppendStmt(stmt ast.Stmt) error {
+	if stmt == nil {
+		return errors.New("no statement to append")
+	}
+
+	endNode := cfg.endNode()
+	if endNode == nil {
+		return errors.New("control flow graph ends in a return statement, the appended statement is unreachable")
+	}
+	endBlock := endNode.Value.(*bblock.BasicBlock)
+
+	var stmtSource bytes.Buffer
+	if err := format.Node(&stmtSource, token.NewFileSet(), stmt); err != nil {
+		return err
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode([]byte("package main\n\nfunc main() {\n" +
+		stmtSource.String() + "\n}\n"))
+	if err != nil {
+		return err
+	}
+
+	//Function literals in the statement are separate functions, following the function itself.
+	stmtBlocks := basicBlocks[1:]
+	for index, basicBlock := range stmtBlocks {
+		if basicBlock.Type == bblock.FUNCTION_ENTRY {
+			stmtBlocks = stmtBlocks[:index]
+			break
+		}
+	}
+
+	//The statement starts on the line of the closing brace, moving it down.
+	for index, basicBlock := range stmtBlocks {
+		basicBlock.Number = endBlock.Number + index
+		basicBlock.StartLine += endBlock.EndLine - stmtLine
+		basicBlock.EndLine += endBlock.EndLine - stmtLine
+		basicBlock.FunctionName = endBlock.FunctionName
+	}
+
+	//Returns and deferred calls continue with the deferred calls already in the function.
+	exitNode := endNode.GetOutNodes()[0]
+
+	inNodes := append([]*graph.Node{}, endNode.GetInNodes()...)
+	cfg.RemoveNode(endNode)
+
+	for _, inNode := range inNodes {
+		for _, successorBlock := range basicBlocks[0].GetSuccessorBlocks() {
+			cfg.InsertEdge(inNode, &graph.Node{Value: successorBlock})
+		}
+	}
+	for _, basicBlock := range stmtBlocks {
+		for _, successorBlock := range basicBlock.GetSuccessorBlocks() {
+			cfg.InsertEdge(&graph.Node{Value: basicBlock}, &graph.Node{Value: successorBlock})
+		}
+		if basicBlock.SuccessorCount() == 0 {
+			cfg.InsertEdge(&graph.Node{Value: basicBlock}, exitNode)
+		}
+		if basicBlock.CallsRecover() {
+			cfg.InsertEdge(&graph.Node{Value: basicBlock}, cfg.Nodes[bblock.NewBasicBlock(-1, bblock.EXIT, 0).UID()])
+		}
+	}
+	return nil
+}
+
+// endNode returns the node in cfg returning at the closing brace of the function body, or
+// nil if the body ends in a return statement.
+func (cfg *ControlFlowGraph) endNode() *graph.Node {
+	for _, node := range cfg.Nodes {
+		if node.Value.(*bblock.BasicBlock).ImplicitReturn() {
+			return node
+		}
+	}
+	return nil
+}
@@ -10,8 +10,11 @@ import (
 	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/graph"
 	"github.com/chrisbbe/GoAnalysis/analyzer/globalvars"
 	"io"
+	"math"
 	"os"
 	"os/exec"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -41,13 +44,9 @@ func (controlFlowGraph ControlFlowGraph) Draw(name string) error {
 	content.WriteString("/* --------------------------------------------------- */\n")
 
 	// Start writing the graph.
-	content.WriteString("digraph AST {\n")
-	for _, node := range controlFlowGraph.Nodes {
-		for _, outNode := range node.GetOutNodes() {
-			content.WriteString(fmt.Sprintf("\t\"%s\" -> \"%s\";\n", node, outNode))
-		}
+	if err := controlFlowGraph.WriteDot(&content, "AST"); err != nil {
+		return err
 	}
-	content.WriteString("}\n")
 
 	if _, err := io.WriteString(dottyFile, content.String()); err != nil {
 		return err
@@ -56,9 +55,47 @@ func (controlFlowGraph ControlFlowGraph) Draw(name string) error {
 	return cmd.Run()
 }
 
+// WriteDot writes the graph named name to w in the Graphviz (www.graphviz.org) DOT format, as
+// written by Draw. Edges are written in the order of their source blocks, from the START node to
+// the EXIT node, so the same graph is always written the same.
+func (controlFlowGraph ControlFlowGraph) WriteDot(w io.Writer, name string) error {
+	nodes := make([]*graph.Node, 0, len(controlFlowGraph.Nodes))
+	for _, node := range controlFlowGraph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return dotOrder(nodes[i]) < dotOrder(nodes[j])
+	})
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "digraph %q {\n", name)
+	for _, node := range nodes {
+		for _, outNode := range node.GetOutNodes() {
+			fmt.Fprintf(&content, "\t\"%s\" -> \"%s\";\n", node, outNode)
+		}
+	}
+	content.WriteString("}\n")
+	_, err := io.WriteString(w, content.String())
+	return err
+}
+
+// dotOrder returns the position of node in the output of WriteDot, the START node first and
+// the EXIT node last.
+func dotOrder(node *graph.Node) int {
+	basicBlock := node.Value.(*bblock.BasicBlock)
+	switch basicBlock.Type {
+	case bblock.START:
+		return -1
+	case bblock.EXIT:
+		return math.MaxInt
+	}
+	return basicBlock.Number
+}
+
 // GetControlFlowGraph generates the control flow graph for each function or
 // method found in the sequence of basic-blocks. Returning an array of control
-// flow graphs where each entry represents an function or method.
+// flow graphs where each entry represents an function or method, empty if
+// there is no FUNCTION_ENTRY block, as for a file without functions.
 func GetControlFlowGraph(basicBlocks []*bblock.BasicBlock) (cfg []*ControlFlowGraph) {
 	prevFunctionBlockIndex := -1 // -1 indicates that no FUNCTION_ENTRY has been found.
 
@@ -71,6 +108,9 @@ func GetControlFlowGraph(basicBlocks []*bblock.BasicBlock) (cfg []*ControlFlowGr
 			prevFunctionBlockIndex = index // Track which block was the last FUNCTION_ENTRY block.
 		}
 	}
+	if prevFunctionBlockIndex == -1 {
+		return cfg
+	}
 	cfg = append(cfg, getControlFlowGraph(basicBlocks[prevFunctionBlockIndex:len(basicBlocks)])) //
 	return cfg
 }
@@ -80,14 +120,10 @@ func getControlFlowGraph(basicBlocks []*bblock.BasicBlock) *ControlFlowGraph {
 	//controlFlowGraph := graph.NewGraph()
 	//var controlFlowGraph ControlFlowGraph
 	controlFlowGraph := New()
-	var lastBlockAdded *bblock.BasicBlock
 
 	for _, basicBlock := range basicBlocks {
-		lastBlockAdded = basicBlock
-
 		for _, successorBlock := range basicBlock.GetSuccessorBlocks() {
 			controlFlowGraph.InsertEdge(&graph.Node{Value: basicBlock}, &graph.Node{Value: successorBlock})
-			lastBlockAdded = successorBlock
 		}
 	}
 
@@ -95,8 +131,196 @@ func getControlFlowGraph(basicBlocks []*bblock.BasicBlock) *ControlFlowGraph {
 	exitNode := &graph.Node{Value: bblock.NewBasicBlock(-1, bblock.EXIT, 0)}
 
 	controlFlowGraph.InsertEdge(startNode, controlFlowGraph.Root)
-	controlFlowGraph.InsertEdge(&graph.Node{Value: lastBlockAdded}, exitNode)
+
+	//Blocks without successors, as return statements and the first deferred call, are joined in one exit.
+	//A panic not recovered by recover() continues, leaving the function directly.
+	for _, basicBlock := range basicBlocks {
+		if basicBlock.SuccessorCount() == 0 || basicBlock.CallsRecover() {
+			controlFlowGraph.InsertEdge(&graph.Node{Value: basicBlock}, exitNode)
+		}
+	}
 	controlFlowGraph.InsertEdge(exitNode, startNode)
 
 	return controlFlowGraph
 }
+
+// PredicateNodeCount returns the number of predicate nodes in cfg, nodes with two or
+// more outgoing edges. When every decision is binary, the cyclomatic complexity
+// equals the count plus one, while a multi-way decision with n outgoing edges is a
+// single predicate node adding n-1 to the complexity.
+func PredicateNodeCount(cfg *ControlFlowGraph) (count int) {
+	for _, node := range cfg.Nodes {
+		if node.GetOutDegree() >= 2 {
+			count++
+		}
+	}
+	return count
+}
+
+// StableID returns an identifier of basicBlock in cfg which, unlike the line based UID,
+// does not change when code is added or removed outside the function. The identifier is
+// the index path of the outgoing edges followed from the function entry to first reach
+// the block in a depth-first traversal, and the block type, as in 0.1.0:FOR_BODY. An
+// empty string is returned if basicBlock is not in cfg.
+func StableID(cfg *ControlFlowGraph, basicBlock *bblock.BasicBlock) string {
+	target := cfg.Nodes[basicBlock.UID()]
+	if target == nil {
+		return ""
+	}
+
+	visited := map[*graph.Node]bool{}
+	var path []string
+	var find func(node *graph.Node, index int) bool
+	find = func(node *graph.Node, index int) bool {
+		if visited[node] {
+			return false
+		}
+		visited[node] = true
+		path = append(path, fmt.Sprintf("%d", index))
+		if node == target {
+			return true
+		}
+		for outIndex, outNode := range node.GetOutNodes() {
+			if find(outNode, outIndex) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if !find(cfg.Root, 0) {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s", strings.Join(path, "."), target.Value.(*bblock.BasicBlock).Type)
+}
+
+// isMetaNode reports whether node holds the START or EXIT meta-block.
+func isMetaNode(node *graph.Node) bool {
+	basicBlock := node.Value.(*bblock.BasicBlock)
+	return basicBlock.Type == bblock.START || basicBlock.Type == bblock.EXIT
+}
+
+// backEdges returns the edges of cfg to a node on the stack of a depth-first traversal
+// from the function entry, as pairs of source and target node. Meta-blocks are skipped.
+func backEdges(cfg *ControlFlowGraph) (edges [][2]*graph.Node) {
+	visited := map[*graph.Node]bool{}
+	onStack := map[*graph.Node]bool{}
+
+	var dfs func(node *graph.Node)
+	dfs = func(node *graph.Node) {
+		visited[node] = true
+		onStack[node] = true
+		for _, outNode := range node.GetOutNodes() {
+			if isMetaNode(outNode) {
+				continue
+			}
+			if onStack[outNode] {
+				edges = append(edges, [2]*graph.Node{node, outNode})
+			} else if !visited[outNode] {
+				dfs(outNode)
+			}
+		}
+		onStack[node] = false
+	}
+	dfs(cfg.Root)
+
+	return edges
+}
+
+// loopNodes returns the loop closed by the back edge from node to header, the header
+// and all nodes reaching node without passing the header. Meta-blocks are left out.
+func loopNodes(node, header *graph.Node) map[*graph.Node]bool {
+	loop := map[*graph.Node]bool{header: true}
+	var addNodes func(node *graph.Node)
+	addNodes = func(node *graph.Node) {
+		if loop[node] || isMetaNode(node) {
+			return
+		}
+		loop[node] = true
+		for _, inNode := range node.GetInNodes() {
+			addNodes(inNode)
+		}
+	}
+	addNodes(node)
+	return loop
+}
+
+// isIrreducible reports whether loop, closed by a back edge to header, is also entered
+// from the function entry without passing the header, having more than one entry.
+func isIrreducible(cfg *ControlFlowGraph, header *graph.Node, loop map[*graph.Node]bool) bool {
+	return header != cfg.Root && loop[cfg.Root]
+}
+
+// naturalLoops returns the nodes in each loop of cfg, keyed by loop header, the loops of its
+// basic-blocks found by bblock.NaturalLoops. Irreducible loops are left out as they have no
+// single header.
+func naturalLoops(cfg *ControlFlowGraph) map[*graph.Node]map[*graph.Node]bool {
+	blocks := []*bblock.BasicBlock{}
+	for _, node := range cfg.Nodes {
+		if !isMetaNode(node) {
+			blocks = append(blocks, node.Value.(*bblock.BasicBlock))
+		}
+	}
+
+	loops := map[*graph.Node]map[*graph.Node]bool{}
+	for _, loop := range bblock.NaturalLoops(blocks) {
+		header := cfg.Nodes[loop.Header.UID()]
+		loops[header] = map[*graph.Node]bool{}
+		for _, block := range loop.Body {
+			loops[header][cfg.Nodes[block.UID()]] = true
+		}
+	}
+	return loops
+}
+
+// IrreducibleLoopCount returns the number of irreducible loops in cfg, loops entered
+// at more than one node as built from goto statements. Irreducible loops have no single
+// header, and are not counted by loop based metrics such as LoopWeightedComplexity.
+func IrreducibleLoopCount(cfg *ControlFlowGraph) (count int) {
+	for _, edge := range backEdges(cfg) {
+		if isIrreducible(cfg, edge[1], loopNodes(edge[0], edge[1])) {
+			count++
+		}
+	}
+	return count
+}
+
+// LoopWeightedComplexity returns the cyclomatic complexity of cfg where decisions inside
+// loops are weighted by nesting, approximating algorithmic hotspots. Each predicate node
+// adds its number of outgoing edges minus one, doubled for every loop enclosing it, so
+// the decisions in a loop body count twice and in a doubly nested loop body four times.
+// Without loops the result equals the cyclomatic complexity.
+func LoopWeightedComplexity(cfg *ControlFlowGraph) int {
+	loops := naturalLoops(cfg)
+
+	complexity := 1
+	for _, node := range cfg.Nodes {
+		if node.GetOutDegree() < 2 {
+			continue
+		}
+		depth := 0
+		for header, loop := range loops {
+			if header != node && loop[node] {
+				depth++
+			}
+		}
+		complexity += (node.GetOutDegree() - 1) << uint(depth)
+	}
+	return complexity
+}
+
+// HighDegreeBlocks returns the basic-blocks in cfg with at least minDegree outgoing edges,
+// ordered by block number. Blocks branching to many successors, such as large switch
+// statements, are where a function branches heavily.
+func HighDegreeBlocks(cfg *ControlFlowGraph, minDegree int) (blocks []*bblock.BasicBlock) {
+	for _, node := range cfg.Nodes {
+		if !isMetaNode(node) && node.GetOutDegree() >= minDegree {
+			blocks = append(blocks, node.Value.(*bblock.BasicBlock))
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Number < blocks[j].Number
+	})
+	return blocks
+}
@@ -8,7 +8,11 @@ import (
 	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
 	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/cfgraph"
 	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/graph"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
+	"strings"
 	"testing"
 )
 
@@ -98,11 +102,13 @@ func TestSimpleControlFlowGraph(t *testing.T) {
 	EXIT := bblock.NewBasicBlock(-1, bblock.EXIT, 0)
 
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
-	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 10)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 9)
+	BB2 := bblock.NewBasicBlock(2, bblock.RETURN_STMT, 10)
 
 	BB0.AddSuccessorBlock(BB1)
+	BB1.AddSuccessorBlock(BB2)
 
-	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1}
+	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1, BB2}
 
 	//Test basic-blocks.
 	if err := VerifyBasicBlocks(basicBlocks, correctBasicBlocks); err != nil {
@@ -111,7 +117,8 @@ func TestSimpleControlFlowGraph(t *testing.T) {
 
 	correctGraph.InsertEdge(&graph.Node{Value: START}, &graph.Node{Value: BB0})
 	correctGraph.InsertEdge(&graph.Node{Value: BB0}, &graph.Node{Value: BB1})
-	correctGraph.InsertEdge(&graph.Node{Value: BB1}, &graph.Node{Value: EXIT})
+	correctGraph.InsertEdge(&graph.Node{Value: BB1}, &graph.Node{Value: BB2})
+	correctGraph.InsertEdge(&graph.Node{Value: BB2}, &graph.Node{Value: EXIT})
 	correctGraph.InsertEdge(&graph.Node{Value: EXIT}, &graph.Node{Value: START})
 
 	if err := VerifyControlFlowGraphs(expectedGraph[0], correctGraph); err != nil {
@@ -184,13 +191,15 @@ func TestForLoopControlFlowGraph(t *testing.T) {
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
 	BB1 := bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 11)
 	BB2 := bblock.NewBasicBlock(2, bblock.FOR_BODY, 14)
-	BB3 := bblock.NewBasicBlock(3, bblock.RETURN_STMT, 16)
+	BB3 := bblock.NewBasicBlock(3, bblock.CALL_EXPRESSION, 15)
+	BB4 := bblock.NewBasicBlock(4, bblock.RETURN_STMT, 16)
 
 	BB0.AddSuccessorBlock(BB1)
 	BB1.AddSuccessorBlock(BB2, BB3)
 	BB2.AddSuccessorBlock(BB1)
+	BB3.AddSuccessorBlock(BB4)
 
-	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1, BB2, BB3}
+	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1, BB2, BB3, BB4}
 
 	//Test basic-blocks.
 	if err := VerifyBasicBlocks(basicBlocks, correctBasicBlocks); err != nil {
@@ -202,7 +211,8 @@ func TestForLoopControlFlowGraph(t *testing.T) {
 	correctGraph.InsertEdge(&graph.Node{Value: BB1}, &graph.Node{Value: BB2})
 	correctGraph.InsertEdge(&graph.Node{Value: BB2}, &graph.Node{Value: BB1})
 	correctGraph.InsertEdge(&graph.Node{Value: BB1}, &graph.Node{Value: BB3})
-	correctGraph.InsertEdge(&graph.Node{Value: BB3}, &graph.Node{Value: EXIT})
+	correctGraph.InsertEdge(&graph.Node{Value: BB3}, &graph.Node{Value: BB4})
+	correctGraph.InsertEdge(&graph.Node{Value: BB4}, &graph.Node{Value: EXIT})
 	correctGraph.InsertEdge(&graph.Node{Value: EXIT}, &graph.Node{Value: START})
 
 	//Test control-flow-graph.
@@ -231,22 +241,24 @@ func TestSwitchControlFlowGraph(t *testing.T) {
 
 	// Function 'main'
 	BB0 := bblock.NewBasicBlock(0, bblock.FUNCTION_ENTRY, 8)
-	BB1 := bblock.NewBasicBlock(1, bblock.RETURN_STMT, 11)
+	BB1 := bblock.NewBasicBlock(1, bblock.CALL_EXPRESSION, 10)
+	BB2 := bblock.NewBasicBlock(2, bblock.RETURN_STMT, 11)
 
 	// Function 'integerToString'
-	BB2 := bblock.NewBasicBlock(2, bblock.FUNCTION_ENTRY, 13)
-	BB3 := bblock.NewBasicBlock(3, bblock.SWITCH_STATEMENT, 14)
-	BB4 := bblock.NewBasicBlock(4, bblock.RETURN_STMT, 16)
-	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 18)
-	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 20)
-	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 22)
-	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 24)
+	BB3 := bblock.NewBasicBlock(3, bblock.FUNCTION_ENTRY, 13)
+	BB4 := bblock.NewBasicBlock(4, bblock.SWITCH_STATEMENT, 14)
+	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 16)
+	BB6 := bblock.NewBasicBlock(6, bblock.RETURN_STMT, 18)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 20)
+	BB8 := bblock.NewBasicBlock(8, bblock.RETURN_STMT, 22)
+	BB9 := bblock.NewBasicBlock(9, bblock.RETURN_STMT, 24)
 
 	BB0.AddSuccessorBlock(BB1)
-	BB2.AddSuccessorBlock(BB3)
-	BB3.AddSuccessorBlock(BB4, BB5, BB6, BB7, BB8)
+	BB1.AddSuccessorBlock(BB2)
+	BB3.AddSuccessorBlock(BB4)
+	BB4.AddSuccessorBlock(BB5, BB6, BB7, BB8) //With a default clause the closing return is never reached.
 
-	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8}
+	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7, BB8, BB9}
 
 	//Test basic-blocks.
 	if err := VerifyBasicBlocks(basicBlocks, correctBasicBlocks); err != nil {
@@ -256,18 +268,22 @@ func TestSwitchControlFlowGraph(t *testing.T) {
 	// Control flow graph for function 'main'.
 	correctGraph[0].InsertEdge(&graph.Node{Value: START}, &graph.Node{Value: BB0})
 	correctGraph[0].InsertEdge(&graph.Node{Value: BB0}, &graph.Node{Value: BB1})
-	correctGraph[0].InsertEdge(&graph.Node{Value: BB1}, &graph.Node{Value: EXIT})
+	correctGraph[0].InsertEdge(&graph.Node{Value: BB1}, &graph.Node{Value: BB2})
+	correctGraph[0].InsertEdge(&graph.Node{Value: BB2}, &graph.Node{Value: EXIT})
 	correctGraph[0].InsertEdge(&graph.Node{Value: EXIT}, &graph.Node{Value: START})
 
 	// Control flow graph for function 'integerToString'.
-	correctGraph[1].InsertEdge(&graph.Node{Value: START}, &graph.Node{Value: BB2})
-	correctGraph[1].InsertEdge(&graph.Node{Value: BB2}, &graph.Node{Value: BB3})
+	correctGraph[1].InsertEdge(&graph.Node{Value: START}, &graph.Node{Value: BB3})
 	correctGraph[1].InsertEdge(&graph.Node{Value: BB3}, &graph.Node{Value: BB4})
-	correctGraph[1].InsertEdge(&graph.Node{Value: BB3}, &graph.Node{Value: BB5})
-	correctGraph[1].InsertEdge(&graph.Node{Value: BB3}, &graph.Node{Value: BB6})
-	correctGraph[1].InsertEdge(&graph.Node{Value: BB3}, &graph.Node{Value: BB7})
-	correctGraph[1].InsertEdge(&graph.Node{Value: BB3}, &graph.Node{Value: BB8})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB4}, &graph.Node{Value: BB5})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB4}, &graph.Node{Value: BB6})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB4}, &graph.Node{Value: BB7})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB4}, &graph.Node{Value: BB8})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB5}, &graph.Node{Value: EXIT}) //Every return is joined in the exit.
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB6}, &graph.Node{Value: EXIT})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB7}, &graph.Node{Value: EXIT})
 	correctGraph[1].InsertEdge(&graph.Node{Value: BB8}, &graph.Node{Value: EXIT})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB9}, &graph.Node{Value: EXIT})
 	correctGraph[1].InsertEdge(&graph.Node{Value: EXIT}, &graph.Node{Value: START})
 
 	if err := VerifyControlFlowGraphs(expectedGraphs[0], correctGraph[0]); err != nil {
@@ -306,14 +322,18 @@ func TestGreatestCommonDivisorControlFlowGraph(t *testing.T) {
 	START1 := bblock.NewBasicBlock(-1, bblock.START, 0)
 	EXIT1 := bblock.NewBasicBlock(-1, bblock.EXIT, 0)
 	BB4 := bblock.NewBasicBlock(4, bblock.FUNCTION_ENTRY, 15)
-	BB5 := bblock.NewBasicBlock(5, bblock.RETURN_STMT, 18)
+	BB5 := bblock.NewBasicBlock(5, bblock.CALL_EXPRESSION, 16)
+	BB6 := bblock.NewBasicBlock(6, bblock.CALL_EXPRESSION, 17)
+	BB7 := bblock.NewBasicBlock(7, bblock.RETURN_STMT, 18)
 
 	BB0.AddSuccessorBlock(BB1)
 	BB1.AddSuccessorBlock(BB2, BB3)
 	BB2.AddSuccessorBlock(BB1)
 	BB4.AddSuccessorBlock(BB5)
+	BB5.AddSuccessorBlock(BB6)
+	BB6.AddSuccessorBlock(BB7)
 
-	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1, BB2, BB3, BB4, BB5}
+	correctBasicBlocks := []*bblock.BasicBlock{BB0, BB1, BB2, BB3, BB4, BB5, BB6, BB7}
 
 	// Function 'gcd'
 	correctGraph[0].InsertEdge(&graph.Node{Value: START0}, &graph.Node{Value: BB0})
@@ -327,7 +347,9 @@ func TestGreatestCommonDivisorControlFlowGraph(t *testing.T) {
 	// Function 'main'
 	correctGraph[1].InsertEdge(&graph.Node{Value: START1}, &graph.Node{Value: BB4})
 	correctGraph[1].InsertEdge(&graph.Node{Value: BB4}, &graph.Node{Value: BB5})
-	correctGraph[1].InsertEdge(&graph.Node{Value: BB5}, &graph.Node{Value: EXIT1})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB5}, &graph.Node{Value: BB6})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB6}, &graph.Node{Value: BB7})
+	correctGraph[1].InsertEdge(&graph.Node{Value: BB7}, &graph.Node{Value: EXIT1})
 	correctGraph[1].InsertEdge(&graph.Node{Value: EXIT1}, &graph.Node{Value: START1})
 
 	// Test basic-blocks.
@@ -342,3 +364,312 @@ func TestGreatestCommonDivisorControlFlowGraph(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPredicateNodeCount(t *testing.T) {
+	testCases := []struct {
+		sourceFile string
+		predicates []int //Predicate nodes in each function.
+		complexity []int //Cyclomatic complexity of each function.
+	}{
+		{"./testcode/_gcd.go", []int{1, 0}, []int{2, 1}},
+		{"./testcode/_switcher.go", []int{0, 1}, []int{1, 4}},
+	}
+
+	for _, testCase := range testCases {
+		sourceFile, err := ioutil.ReadFile(testCase.sourceFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for index, cfg := range cfgraph.GetControlFlowGraph(basicBlocks) {
+			if count := cfgraph.PredicateNodeCount(cfg); count != testCase.predicates[index] {
+				t.Errorf("Number of predicate nodes in function nr. %d in %s should be %d, but are %d!\n", index,
+					testCase.sourceFile, testCase.predicates[index], count)
+			}
+
+			//Each predicate node adds its number of outgoing edges minus one to the complexity.
+			decisions := 1
+			for _, node := range cfg.Nodes {
+				if node.GetOutDegree() >= 2 {
+					decisions += node.GetOutDegree() - 1
+				}
+			}
+			//The edge from exit back to start is not a decision, unreachable blocks neither.
+			complexity := (cfg.GetNumberOfEdges() - 1) - cfg.GetNumberOfNodes() + 2
+			if decisions != complexity || complexity != testCase.complexity[index] {
+				t.Errorf("Complexity of function nr. %d in %s should be %d from both predicate nodes and edges, "+
+					"but are %d and %d!\n", index, testCase.sourceFile, testCase.complexity[index], decisions, complexity)
+			}
+		}
+	}
+}
+
+func TestStableID(t *testing.T) {
+	sourceFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//Unrelated lines added above both functions.
+	editedSourceFile := strings.Replace(string(sourceFile), "import \"fmt\"\n",
+		"import \"fmt\"\n\nvar unused = 0\n\n// gcd returns the greatest common divisor.\n", 1)
+
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	editedBasicBlocks, err := bblock.GetBasicBlocksFromSourceCode([]byte(editedSourceFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	graphs := cfgraph.GetControlFlowGraph(basicBlocks)
+	editedGraphs := cfgraph.GetControlFlowGraph(editedBasicBlocks)
+
+	correctIDs := []string{
+		"0:FUNCTION_ENTRY", "0.0:FOR_STATEMENT", "0.0.0:FOR_BODY", "0.0.1:RETURN_STMT",
+		"0:FUNCTION_ENTRY", "0.0:CALL_EXPRESSION", "0.0.0:CALL_EXPRESSION", "0.0.0.0:RETURN_STMT",
+	}
+	graphIndex := []int{0, 0, 0, 0, 1, 1, 1, 1}
+
+	for index, basicBlock := range basicBlocks {
+		editedBasicBlock := editedBasicBlocks[index]
+		if basicBlock.UID() == editedBasicBlock.UID() {
+			t.Errorf("UID of basic block nr. %d should change when lines are added above it!\n", index)
+		}
+
+		id := cfgraph.StableID(graphs[graphIndex[index]], basicBlock)
+		editedID := cfgraph.StableID(editedGraphs[graphIndex[index]], editedBasicBlock)
+		if id != correctIDs[index] || editedID != correctIDs[index] {
+			t.Errorf("Stable ID of basic block nr. %d should be %s before and after edit, and not %s and %s!\n",
+				index, correctIDs[index], id, editedID)
+		}
+	}
+
+	if id := cfgraph.StableID(graphs[0], basicBlocks[4]); id != "" {
+		t.Errorf("Stable ID of basic block nr. 4 in function gcd should be empty, and not %s!\n", id)
+	}
+}
+
+func TestIrreducibleControlFlowGraph(t *testing.T) {
+	sourceFile, err := ioutil.ReadFile("./testcode/_irreducible.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphs := cfgraph.GetControlFlowGraph(basicBlocks)
+	if len(graphs) != 2 {
+		t.Fatalf("Number of control-flow graphs should be 2, but are %d!\n", len(graphs))
+	}
+
+	//The loop through odd and even is entered at both labels, the loop at even alone is natural.
+	correctIrreducibleLoops := []int{1, 0}
+	correctComplexity := []int{5, 1}
+	for index, cfg := range graphs {
+		if count := cfgraph.IrreducibleLoopCount(cfg); count != correctIrreducibleLoops[index] {
+			t.Errorf("Number of irreducible loops in function nr. %d should be %d, but are %d!\n", index,
+				correctIrreducibleLoops[index], count)
+		}
+		if complexity := cfgraph.LoopWeightedComplexity(cfg); complexity != correctComplexity[index] {
+			t.Errorf("Loop weighted complexity of function nr. %d should be %d, but is %d!\n", index,
+				correctComplexity[index], complexity)
+		}
+	}
+}
+
+func TestLoopWeightedComplexity(t *testing.T) {
+	sourceFile, err := ioutil.ReadFile("./testcode/_nestedloops.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Each level of nesting doubles the weight of the loops within.
+	correctComplexity := map[string]int{"single": 2, "double": 4, "triple": 8, "main": 1}
+
+	graphs := cfgraph.GetControlFlowGraph(basicBlocks)
+	if len(graphs) != len(correctComplexity) {
+		t.Fatalf("Number of control-flow graphs should be %d, but are %d!\n", len(correctComplexity), len(graphs))
+	}
+	for _, cfg := range graphs {
+		name := cfg.Root.Value.(*bblock.BasicBlock).FunctionName
+		if complexity := cfgraph.LoopWeightedComplexity(cfg); complexity != correctComplexity[name] {
+			t.Errorf("Loop weighted complexity of function %s should be %d, but is %d!\n", name,
+				correctComplexity[name], complexity)
+		}
+	}
+}
+
+func TestAppendStmtControlFlowGraph(t *testing.T) {
+	sourceFile, err := ioutil.ReadFile("./testcode/_accumulate.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		stmt string
+	}{
+		{"assignment", "\ttotal = 0\n"},
+		{"call", "\tfmt.Println(n)\n"},
+		{"if-else", "\tif total > 100 {\n\t\tfmt.Println(\"large\")\n\t} else {\n\t\tfmt.Println(\"small\")\n\t}\n"},
+		{"loop", "\tfor total > 0 {\n\t\ttotal--\n\t}\n"},
+		{"return", "\tif total > 0 {\n\t\treturn\n\t}\n"},
+		{"defer", "\tdefer fmt.Println(total)\n"},
+	}
+
+	for _, testCase := range testCases {
+		basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		appendedGraph := cfgraph.GetControlFlowGraph(basicBlocks)[0]
+
+		//The full rebuild has the statement inserted before the closing brace.
+		rebuiltSource := strings.TrimSuffix(string(sourceFile), "}\n") + testCase.stmt + "}\n"
+		file, err := parser.ParseFile(token.NewFileSet(), "", rebuiltSource, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := file.Decls[len(file.Decls)-1].(*ast.FuncDecl).Body
+		if err := appendedGraph.AppendStmt(body.List[len(body.List)-1]); err != nil {
+			t.Fatalf("%s: %s", testCase.name, err)
+		}
+
+		rebuiltBlocks, err := bblock.GetBasicBlocksFromSourceCode([]byte(rebuiltSource))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rebuiltGraph := cfgraph.GetControlFlowGraph(rebuiltBlocks)[0]
+
+		if err := VerifyControlFlowGraphs(appendedGraph, rebuiltGraph.Graph); err != nil {
+			t.Errorf("%s: %s", testCase.name, err)
+		}
+		for key, rebuiltNode := range rebuiltGraph.Nodes {
+			if appendedNode, ok := appendedGraph.Nodes[key]; ok && appendedNode.String() != rebuiltNode.String() {
+				t.Errorf("%s: Node should be %s, but is %s!\n", testCase.name, rebuiltNode, appendedNode)
+			}
+		}
+	}
+}
+
+func TestControlFlowGraphWithoutFunctions(t *testing.T) {
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode([]byte("// Package doc holds no functions.\npackage doc\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graphs := cfgraph.GetControlFlowGraph(basicBlocks); len(graphs) != 0 {
+		t.Errorf("Number of control-flow graphs should be 0, but are %d!\n", len(graphs))
+	}
+}
+
+func TestAppendStmtAfterReturn(t *testing.T) {
+	sourceFile, err := ioutil.ReadFile("./testcode/_total.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := cfgraph.GetControlFlowGraph(basicBlocks)[0]
+	nodes, edges := cfg.GetNumberOfNodes(), cfg.GetNumberOfEdges()
+
+	//A statement after the final return statement is never reached, the graph is kept.
+	stmt := &ast.ExprStmt{X: &ast.CallExpr{Fun: ast.NewIdent("println")}}
+	if err := cfg.AppendStmt(stmt); err == nil {
+		t.Error("Appending a statement after the final return statement should fail!")
+	}
+	if cfg.GetNumberOfNodes() != nodes || cfg.GetNumberOfEdges() != edges {
+		t.Errorf("Control flow graph should have %d nodes and %d edges, but has %d and %d!\n", nodes, edges,
+			cfg.GetNumberOfNodes(), cfg.GetNumberOfEdges())
+	}
+}
+
+func TestHighDegreeBlocks(t *testing.T) {
+	sourceFile, err := ioutil.ReadFile("./testcode/_weekday.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphs := cfgraph.GetControlFlowGraph(basicBlocks)
+	if len(graphs) != 2 {
+		t.Fatalf("Number of control-flow graphs should be 2, but are %d!\n", len(graphs))
+	}
+
+	testCases := []struct {
+		minDegree int
+		blocks    [][]*bblock.BasicBlock //High degree blocks in each function.
+	}{
+		{2, [][]*bblock.BasicBlock{
+			{bblock.NewBasicBlock(1, bblock.FOR_STATEMENT, 9)},
+			{bblock.NewBasicBlock(6, bblock.SWITCH_STATEMENT, 16), bblock.NewBasicBlock(15, bblock.IF_CONDITION, 34)},
+		}},
+		{8, [][]*bblock.BasicBlock{
+			nil,
+			{bblock.NewBasicBlock(6, bblock.SWITCH_STATEMENT, 16)},
+		}},
+		{10, [][]*bblock.BasicBlock{nil, nil}},
+	}
+
+	for _, testCase := range testCases {
+		for index, cfg := range graphs {
+			blocks := cfgraph.HighDegreeBlocks(cfg, testCase.minDegree)
+			correctBlocks := testCase.blocks[index]
+			if len(blocks) != len(correctBlocks) {
+				t.Errorf("Number of blocks with at least %d successors in function nr. %d should be %d, but are %d!\n",
+					testCase.minDegree, index, len(correctBlocks), len(blocks))
+				continue
+			}
+			for blockIndex, block := range blocks {
+				if block.UID() != correctBlocks[blockIndex].UID() || block.Number != correctBlocks[blockIndex].Number {
+					t.Errorf("Block with at least %d successors should be %s, and not %s!\n", testCase.minDegree,
+						correctBlocks[blockIndex], block)
+				}
+			}
+		}
+	}
+}
+
+func TestWriteDot(t *testing.T) {
+	sourceFile, err := ioutil.ReadFile("./testcode/_simple.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := cfgraph.GetControlFlowGraph(basicBlocks)[0]
+
+	//Edges are written in block order, from START to EXIT.
+	correctDot := "digraph \"main\" {\n" +
+		"\t\"START\" -> \"BLOCK NR.0 (FUNCTION_ENTRY) (EndLine: 8)\";\n" +
+		"\t\"BLOCK NR.0 (FUNCTION_ENTRY) (EndLine: 8)\" -> \"BLOCK NR.1 (CALL_EXPRESSION) (EndLine: 9)\";\n" +
+		"\t\"BLOCK NR.1 (CALL_EXPRESSION) (EndLine: 9)\" -> \"BLOCK NR.2 (RETURN_STMT) (EndLine: 10)\";\n" +
+		"\t\"BLOCK NR.2 (RETURN_STMT) (EndLine: 10)\" -> \"EXIT\";\n" +
+		"\t\"EXIT\" -> \"START\";\n" +
+		"}\n"
+	for i := 0; i < 10; i++ {
+		var dot strings.Builder
+		if err := cfg.WriteDot(&dot, "main"); err != nil {
+			t.Fatal(err)
+		}
+		if dot.String() != correctDot {
+			t.Fatalf("DOT output should be %q, and not %q!\n", correctDot, dot.String())
+		}
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func accumulate(n int) {
+	total := 0
+	for i := 0; i < n; i++ {
+		total += i
+	}
+	fmt.Println(total)
+}
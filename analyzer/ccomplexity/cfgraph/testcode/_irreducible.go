@@ -0,0 +1,39 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+// collatzSteps has a loop with two entries, at odd and at even, giving
+// irreducible control flow. Go does not allow goto into a block, so the
+// loop is built from labels in the function body.
+func collatzSteps(n int) int {
+	steps := 0
+	if n%2 == 0 {
+		goto even
+	} else {
+		steps = 0
+	}
+odd:
+	n = 3*n + 1
+	steps++
+even:
+	n = n / 2
+	steps++
+	if n%2 == 0 && n > 1 {
+		goto even
+	} else {
+		steps += 0
+	}
+	if n > 1 {
+		goto odd
+	} else {
+		steps += 0
+	}
+	return steps
+}
+
+func main() {
+	fmt.Println(collatzSteps(6))
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	for day := 0; day < 8; day++ {
+		fmt.Println(weekday(day))
+	}
+}
+
+func weekday(day int) string {
+	name := ""
+	switch day {
+	case 0:
+		name = "Sunday"
+	case 1:
+		name = "Monday"
+	case 2:
+		name = "Tuesday"
+	case 3:
+		name = "Wednesday"
+	case 4:
+		name = "Thursday"
+	case 5:
+		name = "Friday"
+	case 6:
+		name = "Saturday"
+	default:
+		name = "Invalid day"
+	}
+	if name == "Saturday" || name == "Sunday" {
+		name += " (weekend)"
+	}
+	return name
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/ast"
+	"go/token"
+)
+
+// cognitiveVisitor sums the cognitive complexity increments of the nodes of a function, at
+// the nesting depth of the nodes visited.
+type cognitiveVisitor struct {
+	funcName   string //Name of the function, calling itself adds an increment.
+	nesting    int    //Number of enclosing control structures and function literals.
+	complexity *int   //Cognitive complexity of the function, shared with the nested visitors.
+}
+
+// CognitiveComplexity returns the cognitive complexity of each function in srcFile, keyed by
+// receiver-qualified function name, scored by the SonarSource rules. If statements, loops, switch and select
+// statements add one and their nesting depth, as do else and else if branches without the
+// nesting depth. Bodies of control structures and function literals are nested one level
+// deeper. Each sequence of like boolean operators, goto statements, break and continue to a
+// label, and recursive calls add one more. Nested structures so score higher than flat ones
+// of the same cyclomatic complexity.
+func CognitiveComplexity(srcFile []byte) (map[string]int, error) {
+	_, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	complexities := map[string]int{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		complexity := 0
+		funcName := funcDecl.Name.Name
+		if funcDecl.Recv != nil {
+			funcName = "" //Method calls are not resolved, methods are not checked for recursion.
+		}
+		ast.Walk(&cognitiveVisitor{funcName: funcName, complexity: &complexity}, funcDecl.Body)
+		complexities[bblock.QualifiedFuncName(funcDecl)] = complexity
+	}
+	return complexities, nil
+}
+
+// nested returns a visitor for the nodes nested one level deeper.
+func (v *cognitiveVisitor) nested() *cognitiveVisitor {
+	return &cognitiveVisitor{funcName: v.funcName, nesting: v.nesting + 1, complexity: v.complexity}
+}
+
+// walk visits the nodes, skipping nil nodes as missing init statements.
+func (v *cognitiveVisitor) walk(nodes ...ast.Node) {
+	for _, node := range nodes {
+		if node != nil {
+			ast.Walk(v, node)
+		}
+	}
+}
+
+func (v *cognitiveVisitor) Visit(node ast.Node) ast.Visitor {
+	switch t := node.(type) {
+	case *ast.IfStmt:
+		*v.complexity += 1 + v.nesting
+		v.visitIf(t)
+		return nil
+
+	case *ast.ForStmt:
+		*v.complexity += 1 + v.nesting
+		v.walk(t.Init, t.Cond, t.Post)
+		v.nested().walk(t.Body)
+		return nil
+
+	case *ast.RangeStmt:
+		*v.complexity += 1 + v.nesting
+		v.walk(t.X)
+		v.nested().walk(t.Body)
+		return nil
+
+	case *ast.SwitchStmt:
+		*v.complexity += 1 + v.nesting
+		v.walk(t.Init, t.Tag)
+		v.nested().walk(t.Body)
+		return nil
+
+	case *ast.TypeSwitchStmt:
+		*v.complexity += 1 + v.nesting
+		v.walk(t.Init, t.Assign)
+		v.nested().walk(t.Body)
+		return nil
+
+	case *ast.SelectStmt:
+		*v.complexity += 1 + v.nesting
+		v.nested().walk(t.Body)
+		return nil
+
+	case *ast.FuncLit:
+		v.nested().walk(t.Body)
+		return nil
+
+	case *ast.BranchStmt:
+		if t.Tok == token.GOTO || t.Label != nil {
+			*v.complexity++
+		}
+
+	case *ast.CallExpr:
+		if ident, ok := t.Fun.(*ast.Ident); ok && v.funcName != "" && ident.Name == v.funcName {
+			*v.complexity++
+		}
+
+	case *ast.BinaryExpr:
+		if t.Op != token.LAND && t.Op != token.LOR {
+			break
+		}
+		var operators []token.Token
+		var operands []ast.Expr
+		logicalOperators(t, &operators, &operands)
+		for index, operator := range operators {
+			if index == 0 || operator != operators[index-1] {
+				*v.complexity++ //New sequence of like operators.
+			}
+		}
+		for _, operand := range operands {
+			v.walk(operand)
+		}
+		return nil
+	}
+	return v
+}
+
+// visitIf visits the if statement, and its else if and else branches each adding one.
+func (v *cognitiveVisitor) visitIf(ifStmt *ast.IfStmt) {
+	v.walk(ifStmt.Init, ifStmt.Cond)
+	v.nested().walk(ifStmt.Body)
+	switch elseStmt := ifStmt.Else.(type) {
+	case *ast.IfStmt:
+		*v.complexity++
+		v.visitIf(elseStmt)
+	case *ast.BlockStmt:
+		*v.complexity++
+		v.nested().walk(elseStmt)
+	}
+}
+
+// logicalOperators appends the && and || operators of the boolean expression in source
+// order to operators, looking through parentheses, and the other operands to operands.
+func logicalOperators(expr ast.Expr, operators *[]token.Token, operands *[]ast.Expr) {
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		logicalOperators(t.X, operators, operands)
+		return
+	case *ast.BinaryExpr:
+		if t.Op == token.LAND || t.Op == token.LOR {
+			logicalOperators(t.X, operators, operands)
+			*operators = append(*operators, t.Op)
+			logicalOperators(t.Y, operators, operands)
+			return
+		}
+	}
+	*operands = append(*operands, expr)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestCognitiveComplexity(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_cognitive.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	complexities, err := CognitiveComplexity(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctComplexities := map[string]int{
+		"nested":     6, //Ifs at nesting 0, 1 and 2: 1 + 2 + 3.
+		"guarded":    3, //Ifs at nesting 0: 1 + 1 + 1.
+		"flatSwitch": 1, //Switch, case clauses add nothing.
+		"conditions": 9, //If 1 + 3 operator sequences, else if 1 + 1 sequence, else 1, return 2 sequences.
+		"loops":      7, //For 1, range 2, if 3, continue to label 1.
+		"closure":    2, //If nested in function literal.
+		"factorial":  2, //If 1, recursive call 1.
+	}
+	if len(complexities) != len(correctComplexities) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(correctComplexities), len(complexities))
+	}
+	for name, correctComplexity := range correctComplexities {
+		if complexity := complexities[name]; complexity != correctComplexity {
+			t.Errorf("Cognitive complexity of %s should be %d, but is %d!\n", name, correctComplexity, complexity)
+		}
+	}
+
+	//Equal cyclomatic complexity, but nesting is harder to follow than guard clauses.
+	if complexities["nested"] <= complexities["guarded"] {
+		t.Errorf("Nested ifs should score higher than guard clauses, but score %d and %d!\n", complexities["nested"],
+			complexities["guarded"])
+	}
+}
+
+func TestCognitiveComplexityMethods(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./bblock/testcode/_receivers.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	complexities, err := CognitiveComplexity(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//Methods of the same name are keyed apart by their receiver type.
+	correctComplexities := map[string]int{
+		"celsius.String":  1,
+		"(*point).String": 0,
+		"(*point).Reset":  0,
+		"String":          0,
+		"main":            0,
+	}
+	if len(complexities) != len(correctComplexities) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(correctComplexities), len(complexities))
+	}
+	for name, correctComplexity := range correctComplexities {
+		if complexity, ok := complexities[name]; !ok || complexity != correctComplexity {
+			t.Errorf("Cognitive complexity of %s should be %d, but is %d!\n", name, correctComplexity, complexity)
+		}
+	}
+}
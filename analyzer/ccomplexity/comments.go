@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// DecisionCommentCoverage returns the fraction of decisions in each function in srcFile
+// having an adjacent comment, keyed by receiver-qualified function name. Decisions are if statements, loops
+// and case and comm clauses except default clauses. A decision is commented when a comment
+// ends on the line above it or starts on its line. Functions without decisions are left out.
+func DecisionCommentCoverage(srcFile []byte) (map[string]float64, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", srcFile, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	commentStartLines := map[int]bool{}
+	commentEndLines := map[int]bool{}
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			commentStartLines[fileSet.Position(comment.Pos()).Line] = true
+		}
+		commentEndLines[fileSet.Position(commentGroup.End()).Line] = true
+	}
+
+	coverage := map[string]float64{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		decisions, commented := 0, 0
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			if !isDecision(node) {
+				return true
+			}
+			decisions++
+			line := fileSet.Position(node.Pos()).Line
+			if commentStartLines[line] || commentEndLines[line-1] {
+				commented++
+			}
+			return true
+		})
+		if decisions > 0 {
+			coverage[bblock.QualifiedFuncName(funcDecl)] = float64(commented) / float64(decisions)
+		}
+	}
+	return coverage, nil
+}
+
+// isDecision reports whether node is a decision: an if statement, a loop, or a case or
+// comm clause other than the default clause.
+func isDecision(node ast.Node) bool {
+	switch t := node.(type) {
+	case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+		return true
+	case *ast.CaseClause:
+		return t.List != nil
+	case *ast.CommClause:
+		return t.Comm != nil
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestDecisionCommentCoverage(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_decisioncomments.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	coverage, err := DecisionCommentCoverage(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctCoverage := map[string]float64{
+		"classify": 2.0 / 3.0, //Commented if and case 0, uncommented case 1, default is no decision.
+		"sum":      1.0 / 2.0, //Block comment ending above the second loop.
+	}
+
+	if len(coverage) != len(correctCoverage) {
+		t.Errorf("Number of functions with decisions should be %d, but are %d!\n", len(correctCoverage), len(coverage))
+	}
+	for name, fraction := range correctCoverage {
+		if coverage[name] != fraction {
+			t.Errorf("Function %s should have decision comment coverage %.2f, but has %.2f!\n", name, fraction,
+				coverage[name])
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/cfgraph"
+	"go/ast"
+	"sync"
+)
+
+// declComplexity holds the functions of a single function declaration, the declared function
+// followed by the function literals in it, and their basic-blocks.
+type declComplexity struct {
+	functions []*FunctionComplexity
+	blocks    []*bblock.BasicBlock
+}
+
+// analyzeSourceConcurrently computes cyclomatic complexity for each function in srcFile, read
+// from srcPath, as analyzeSource, but parses srcFile once and builds the control-flow graphs of
+// the function declarations concurrently from the shared syntax tree. The functions are returned
+// in the same order, and the basic-blocks numbered the same, as by analyzeSource.
+func analyzeSourceConcurrently(srcPath string, srcFile []byte) ([]*FunctionComplexity, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcDecls []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+			funcDecls = append(funcDecls, funcDecl)
+		}
+	}
+
+	decls := make([]declComplexity, len(funcDecls))
+	var waitGroup sync.WaitGroup
+	for index, funcDecl := range funcDecls {
+		waitGroup.Add(1)
+		go func(index int, funcDecl *ast.FuncDecl) {
+			defer waitGroup.Done()
+			blocks := bblock.GetBasicBlocksFromFuncDecl(fileSet, funcDecl)
+			for _, cfg := range cfgraph.GetControlFlowGraph(blocks) {
+				functionBlock := cfg.Root.Value.(*bblock.BasicBlock)
+				decls[index].functions = append(decls[index].functions, &FunctionComplexity{
+					Name:             functionBlock.FunctionName,
+					File:             srcPath,
+					Line:             functionBlock.EndLine,
+					Complexity:       GetCyclomaticComplexity(cfg),
+					ControlFlowGraph: cfg,
+				})
+			}
+			decls[index].functions[0].Kind = getTestKind(funcDecl)
+			decls[index].blocks = blocks
+		}(index, funcDecl)
+	}
+	waitGroup.Wait()
+
+	//Declared functions come first, followed by the function literals, as when analyzed sequentially.
+	var functions, literals []*FunctionComplexity
+	var blocks, literalBlocks []*bblock.BasicBlock
+	for _, decl := range decls {
+		functions = append(functions, decl.functions[0])
+		literals = append(literals, decl.functions[1:]...)
+		literalStart := len(decl.blocks)
+		for index, block := range decl.blocks {
+			if index > 0 && block.Type == bblock.FUNCTION_ENTRY {
+				literalStart = index
+				break
+			}
+		}
+		blocks = append(blocks, decl.blocks[:literalStart]...)
+		literalBlocks = append(literalBlocks, decl.blocks[literalStart:]...)
+	}
+	functions = append(functions, literals...)
+	blocks = append(blocks, literalBlocks...)
+
+	for index, block := range blocks {
+		block.Number = index
+	}
+	for _, function := range functions {
+		function.BasicBlocks = blocks
+	}
+	return functions, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// verifySameFunctions checks that the functions found by concurrent analysis are the functions
+// found by sequential analysis, in the same order and with the same basic-blocks.
+func verifySameFunctions(concurrentFunctions, sequentialFunctions []*FunctionComplexity) error {
+	if len(concurrentFunctions) != len(sequentialFunctions) {
+		return fmt.Errorf("Number of functions should be %d, but are %d!\n", len(sequentialFunctions), len(concurrentFunctions))
+	}
+	for index, sequential := range sequentialFunctions {
+		concurrent := concurrentFunctions[index]
+		if concurrent.Name != sequential.Name || concurrent.File != sequential.File || concurrent.Line != sequential.Line ||
+			concurrent.Complexity != sequential.Complexity || concurrent.Kind != sequential.Kind {
+			return fmt.Errorf("Function nr. %d should be %s at line %d with complexity %d, and not %s at line %d with complexity %d!\n",
+				index, sequential.Name, sequential.Line, sequential.Complexity, concurrent.Name, concurrent.Line, concurrent.Complexity)
+		}
+		if concurrent.GetNumberOfNodes() != sequential.GetNumberOfNodes() || concurrent.GetNumberOfEdges() != sequential.GetNumberOfEdges() {
+			return fmt.Errorf("Graph of %s should have %d nodes and %d edges, but has %d nodes and %d edges!\n", sequential.Name,
+				sequential.GetNumberOfNodes(), sequential.GetNumberOfEdges(), concurrent.GetNumberOfNodes(), concurrent.GetNumberOfEdges())
+		}
+		if len(concurrent.BasicBlocks) != len(sequential.BasicBlocks) {
+			return fmt.Errorf("Number of basic-blocks should be %d, but are %d!\n", len(sequential.BasicBlocks), len(concurrent.BasicBlocks))
+		}
+		for blockIndex, sequentialBlock := range sequential.BasicBlocks {
+			if concurrentBlock := concurrent.BasicBlocks[blockIndex]; concurrentBlock.String() != sequentialBlock.String() {
+				return fmt.Errorf("Basic-block nr. %d should be %s, and not %s!\n", blockIndex, sequentialBlock, concurrentBlock)
+			}
+		}
+	}
+	return nil
+}
+
+func TestConcurrentAnalysis(t *testing.T) {
+	srcPaths, err := filepath.Glob("./testcode/_*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, srcPath := range srcPaths {
+		sequentialFunctions, err := NewAnalyzer().AnalyzeFile(srcPath)
+		if err != nil {
+			continue //Only files analyzed sequentially are compared.
+		}
+		concurrentFunctions, err := NewAnalyzer(WithConcurrency(true)).AnalyzeFile(srcPath)
+		if err != nil {
+			t.Fatalf("%s: %s", srcPath, err)
+		}
+		if err := verifySameFunctions(concurrentFunctions, sequentialFunctions); err != nil {
+			t.Errorf("%s: %s", srcPath, err)
+		}
+	}
+}
+
+// writeLargeSource writes a Go source file with functions copies of the functions in
+// _cognitive.go, returning its path.
+func writeLargeSource(b *testing.B, functions int) string {
+	srcFile, err := ioutil.ReadFile("./testcode/_cognitive.go")
+	if err != nil {
+		b.Fatal(err)
+	}
+	body := srcFile[bytes.Index(srcFile, []byte("\nfunc ")):]
+
+	var largeSource bytes.Buffer
+	largeSource.WriteString("package main\n")
+	for copy := 0; copy < functions; copy++ {
+		largeSource.Write(bytes.Replace(body, []byte("\nfunc "), []byte(fmt.Sprintf("\nfunc c%d", copy)), -1))
+	}
+
+	largeFile, err := ioutil.TempFile(b.TempDir(), "large*.go")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer largeFile.Close()
+	if _, err := largeFile.Write(largeSource.Bytes()); err != nil {
+		b.Fatal(err)
+	}
+	return largeFile.Name()
+}
+
+func benchmarkAnalyzeFile(b *testing.B, analyzer *Analyzer) {
+	srcPath := writeLargeSource(b, 50)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := analyzer.AnalyzeFile(srcPath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAnalyzeFileSequential(b *testing.B) {
+	benchmarkAnalyzeFile(b, NewAnalyzer())
+}
+
+func BenchmarkAnalyzeFileConcurrent(b *testing.B) {
+	benchmarkAnalyzeFile(b, NewAnalyzer(WithConcurrency(true)))
+}
@@ -4,15 +4,25 @@
 package ccomplexity
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
 	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/cfgraph"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
 )
 
 // FunctionComplexity represents cyclomatic complexity in a function or method.
 type FunctionComplexity struct {
 	Name             string                    //Function name.
+	File             string                    //Path to source file, empty if unknown.
 	Line             int                       //Line number in source file.
 	Complexity       int                       //Cyclomatic complexity value.
+	Kind             TestKind                  //Kind of test function, NOT_TEST for other functions.
 	ControlFlowGraph *cfgraph.ControlFlowGraph //Control-flow graph in function.
 	BasicBlocks      []*bblock.BasicBlock      //Basic-blocks in function.
 }
@@ -29,8 +39,11 @@ func (function *FunctionComplexity) GetNumberOfSCC() int {
 	return function.ControlFlowGraph.GetNumberOfSCComponents()
 }
 
+// GetCyclomaticComplexity returns the cyclomatic complexity E - N + 2 of cfg, with E edges and N nodes,
+// not counting the edge from the EXIT node back to the START node. As by Complexity, loops never left
+// and blocks never reached are counted in the single component of the function.
 func GetCyclomaticComplexity(cfg *cfgraph.ControlFlowGraph) int {
-	return cfg.GetNumberOfEdges() - cfg.GetNumberOfNodes() + cfg.GetNumberOfSCComponents()
+	return (cfg.GetNumberOfEdges() - 1) - cfg.GetNumberOfNodes() + 2
 }
 
 func GetCyclomaticComplexityFunctionLevel(srcFile []byte) (functions []*FunctionComplexity, err error) {
@@ -38,16 +51,325 @@ func GetCyclomaticComplexityFunctionLevel(srcFile []byte) (functions []*Function
 	if err != nil {
 		return nil, err
 	}
+	testKinds, err := testFunctionKinds(srcFile)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, cfg := range cfgraph.GetControlFlowGraph(blocks) {
 		complexity := GetCyclomaticComplexity(cfg)
+		functionBlock := cfg.Root.Value.(*bblock.BasicBlock)
 		functions = append(functions, &FunctionComplexity{
-			Name:             cfg.Root.Value.(*bblock.BasicBlock).FunctionName,
-			Line:             blocks[0].EndLine,
+			Name:             functionBlock.FunctionName,
+			Line:             functionBlock.EndLine,
 			Complexity:       complexity,
+			Kind:             testKinds[functionBlock.EndLine],
 			ControlFlowGraph: cfg,
 			BasicBlocks:      blocks,
 		})
 	}
 	return functions, nil
 }
+
+// Complexity returns the cyclomatic complexity E - N + 2 of the basic-blocks of a single
+// function, with E edges and N nodes taken from the successors of the blocks. Blocks
+// without successors, as the blocks of several return statements, are joined in one exit,
+// also left by blocks calling recover() when the panic is not recovered. The complexity is
+// the one of the control-flow graph, see GetCyclomaticComplexity.
+func Complexity(blocks []*bblock.BasicBlock) int {
+	if len(blocks) == 0 {
+		return 0
+	}
+	edges, nodes, exits := 0, len(blocks), 0
+	for _, block := range blocks {
+		if successors := block.SuccessorCount(); successors > 0 {
+			edges += successors
+			if block.CallsRecover() {
+				exits++
+			}
+		} else {
+			exits++
+		}
+	}
+	if exits > 0 {
+		edges += exits
+		nodes++ //The exit node.
+	}
+	return edges - nodes + 2
+}
+
+// ComplexityFromSource returns the cyclomatic complexity of each function in srcFile,
+// computed by Complexity and keyed by function name.
+func ComplexityFromSource(srcFile []byte) (map[string]int, error) {
+	functions, err := bblock.GetFunctionBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	complexities := make(map[string]int, len(functions))
+	for name, blocks := range functions {
+		complexities[name] = Complexity(blocks)
+	}
+	return complexities, nil
+}
+
+// ComplexityWithBooleans returns the extended cyclomatic complexity of each function in srcFile,
+// keyed by function name as by ComplexityFromSource. Each && and || operator in the condition of
+// an if statement or for loop counts as an additional decision, also in nested and parenthesized
+// boolean expressions. Conditions in function literals count toward the function literal.
+func ComplexityWithBooleans(srcFile []byte) (map[string]int, error) {
+	complexities, err := ComplexityFromSource(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	//Functions and function literals are named after the function entry block on their line.
+	entryNames := map[int]string{}
+	for _, basicBlock := range basicBlocks {
+		if basicBlock.Type == bblock.FUNCTION_ENTRY {
+			entryNames[basicBlock.EndLine] = basicBlock.FunctionName
+		}
+	}
+
+	var countOperators func(body *ast.BlockStmt, name string)
+	countOperators = func(body *ast.BlockStmt, name string) {
+		ast.Inspect(body, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				countOperators(t.Body, entryNames[fileSet.Position(t.Pos()).Line])
+				return false
+			case *ast.IfStmt:
+				complexities[name] += booleanOperatorCount(t.Cond)
+			case *ast.ForStmt:
+				complexities[name] += booleanOperatorCount(t.Cond)
+			}
+			return true
+		})
+	}
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+			countOperators(funcDecl.Body, entryNames[fileSet.Position(funcDecl.Pos()).Line])
+		}
+	}
+	return complexities, nil
+}
+
+// booleanOperatorCount returns the number of && and || operators in expr, not counting
+// operators in function literals. A nil expression has none.
+func booleanOperatorCount(expr ast.Expr) (count int) {
+	if expr == nil {
+		return 0
+	}
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch t := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BinaryExpr:
+			if t.Op == token.LAND || t.Op == token.LOR {
+				count++
+			}
+		}
+		return true
+	})
+	return count
+}
+
+// MethodComplexity returns the cyclomatic complexity of the method methodName declared
+// on the receiver type typeName in srcFile, with or without pointer receiver.
+func MethodComplexity(srcFile []byte, typeName, methodName string) (FunctionComplexity, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return FunctionComplexity{}, err
+	}
+
+	methodLine := 0
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv != nil && funcDecl.Name.Name == methodName &&
+			bblock.ReceiverTypeName(funcDecl.Recv) == typeName {
+			methodLine = fileSet.Position(funcDecl.Pos()).Line
+			break
+		}
+	}
+	if methodLine == 0 {
+		return FunctionComplexity{}, fmt.Errorf("no method %s.%s", typeName, methodName)
+	}
+
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		return FunctionComplexity{}, err
+	}
+	for _, function := range functions {
+		if function.Line == methodLine {
+			return *function, nil
+		}
+	}
+	return FunctionComplexity{}, fmt.Errorf("no method %s.%s", typeName, methodName)
+}
+
+// maxComplexityDirective is the comment prefix setting the maximum complexity of
+// the function below, as in //maxcomplexity:15.
+const maxComplexityDirective = "//maxcomplexity:"
+
+// maxComplexityOverrides returns the maximum complexity set by a maxcomplexity directive
+// in the doc comment of functions in srcFile, keyed by the line of the function declaration.
+func maxComplexityOverrides(srcFile []byte) (map[int]int, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", srcFile, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[int]int{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Doc == nil {
+			continue
+		}
+		for _, comment := range funcDecl.Doc.List {
+			if !strings.HasPrefix(comment.Text, maxComplexityDirective) {
+				continue
+			}
+			max, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(comment.Text, maxComplexityDirective)))
+			if err != nil {
+				return nil, fmt.Errorf("invalid maxcomplexity directive at line %d: %s",
+					fileSet.Position(comment.Pos()).Line, comment.Text)
+			}
+			overrides[fileSet.Position(funcDecl.Pos()).Line] = max
+		}
+	}
+	return overrides, nil
+}
+
+// AssertMaxComplexity returns an error listing every function in srcFile with
+// cyclomatic complexity above max, or nil if no function exceeds max. A function
+// documented with a //maxcomplexity:N comment is held to N instead of max.
+func AssertMaxComplexity(srcFile []byte, max int) error {
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		return err
+	}
+	overrides, err := maxComplexityOverrides(srcFile)
+	if err != nil {
+		return err
+	}
+
+	var offenders []string
+	for _, function := range functions {
+		if override, ok := overrides[function.Line]; ok {
+			if function.Complexity > override {
+				offenders = append(offenders, fmt.Sprintf("%s (line %d) has complexity %d, above its maxcomplexity %d",
+					function.Name, function.Line, function.Complexity, override))
+			}
+		} else if function.Complexity > max {
+			offenders = append(offenders, fmt.Sprintf("%s (line %d) has complexity %d", function.Name, function.Line,
+				function.Complexity))
+		}
+	}
+
+	if len(offenders) > 0 {
+		return fmt.Errorf("%d function(s) exceed maximum cyclomatic complexity %d: %s", len(offenders), max,
+			strings.Join(offenders, ", "))
+	}
+	return nil
+}
+
+// StmtListComplexity returns the cyclomatic complexity of the statement list as
+// if it was the body of a function. The statements are printed and parsed again,
+// so they need no position information and may be built by hand. Returns 0 if
+// the statements can not be printed as valid Go source code.
+func StmtListComplexity(stmts []ast.Stmt) int {
+	var srcFile bytes.Buffer
+	srcFile.WriteString("package main\n\nfunc main() {\n")
+	for _, stmt := range stmts {
+		if err := format.Node(&srcFile, token.NewFileSet(), stmt); err != nil {
+			return 0
+		}
+		srcFile.WriteString("\n")
+	}
+	srcFile.WriteString("}\n")
+
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile.Bytes())
+	if err != nil || len(functions) == 0 {
+		return 0
+	}
+	return functions[0].Complexity
+}
+
+// SwitchComplexityContribution returns how much the switch or type switch statement
+// starting at switchLine in srcFile adds to the cyclomatic complexity of its function,
+// one for each case clause except the default clause. Case clauses listing several
+// values count once, as they share a single branch.
+func SwitchComplexityContribution(srcFile []byte, switchLine int) (int, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return 0, err
+	}
+
+	var switchBody *ast.BlockStmt
+	ast.Inspect(file, func(node ast.Node) bool {
+		if switchBody != nil {
+			return false
+		}
+		switch t := node.(type) {
+		case *ast.SwitchStmt:
+			if fileSet.Position(t.Pos()).Line == switchLine {
+				switchBody = t.Body
+			}
+		case *ast.TypeSwitchStmt:
+			if fileSet.Position(t.Pos()).Line == switchLine {
+				switchBody = t.Body
+			}
+		}
+		return true
+	})
+	if switchBody == nil {
+		return 0, fmt.Errorf("no switch statement at line %d", switchLine)
+	}
+
+	return switchContribution(switchBody), nil
+}
+
+// switchContribution returns how much the switch or type switch statement with body
+// adds to the cyclomatic complexity, one for each case clause except the default clause.
+func switchContribution(switchBody *ast.BlockStmt) int {
+	contribution := 0
+	for _, stmt := range switchBody.List {
+		if caseClause := stmt.(*ast.CaseClause); caseClause.List != nil {
+			contribution++
+		}
+	}
+	return contribution
+}
+
+// UntestedComplexFunctions returns the functions in results with cyclomatic complexity of
+// at least minComplexity, not in the set of covered function names, in the order of results.
+// These are the functions most in need of tests.
+func UntestedComplexFunctions(results []FunctionComplexity, covered map[string]bool, minComplexity int) []FunctionComplexity {
+	untested := []FunctionComplexity{}
+	for _, function := range results {
+		if function.Complexity >= minComplexity && !covered[function.Name] {
+			untested = append(untested, function)
+		}
+	}
+	return untested
+}
+
+// Budget returns the complexity budget remaining in each function in results, limit minus its
+// cyclomatic complexity, keyed by function name. The budget is negative for functions above
+// limit. Functions sharing a name, as in different files, get the budget of the most complex.
+func Budget(results []FunctionComplexity, limit int) map[string]int {
+	budgets := make(map[string]int, len(results))
+	for _, function := range results {
+		if budget, ok := budgets[function.Name]; !ok || limit-function.Complexity < budget {
+			budgets[function.Name] = limit - function.Complexity
+		}
+	}
+	return budgets
+}
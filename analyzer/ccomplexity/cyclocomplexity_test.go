@@ -5,7 +5,11 @@ package ccomplexity
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
+	"reflect"
 	"testing"
 )
 
@@ -79,7 +83,7 @@ func TestSwitcherComplexity(t *testing.T) {
 
 	correctCyclomaticComplexity := []FunctionComplexity{
 		FunctionComplexity{Name: "main", Complexity: 1},
-		FunctionComplexity{Name: "monthNumberToString", Complexity: 14},
+		FunctionComplexity{Name: "monthNumberToString", Complexity: 13},
 	}
 
 	if err := verifyCyclomaticComplexity(expectedCyclomaticComplexity, correctCyclomaticComplexity); err != nil {
@@ -105,3 +109,429 @@ func TestGreatestCommonDivisorComplexity(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestAssertMaxComplexity(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switcher.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AssertMaxComplexity(srcFile, 14); err != nil {
+		t.Errorf("No function should exceed complexity 14, but got error: %s\n", err)
+	}
+
+	err = AssertMaxComplexity(srcFile, 10)
+	if err == nil {
+		t.Fatal("Function monthNumberToString should exceed complexity 10!")
+	}
+	correctMessage := "1 function(s) exceed maximum cyclomatic complexity 10: monthNumberToString (line 13) has complexity 13"
+	if err.Error() != correctMessage {
+		t.Errorf("Error message should be %q, and not %q!\n", correctMessage, err.Error())
+	}
+}
+
+func TestAssertMaxComplexityOverride(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_maxcomplexity.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = AssertMaxComplexity(srcFile, 2)
+	if err == nil {
+		t.Fatal("Functions sign and countdown should exceed their maximum complexity!")
+	}
+	correctMessage := "2 function(s) exceed maximum cyclomatic complexity 2: sign (line 14) has complexity 2, " +
+		"above its maxcomplexity 1, countdown (line 24) has complexity 3"
+	if err.Error() != correctMessage {
+		t.Errorf("Error message should be %q, and not %q!\n", correctMessage, err.Error())
+	}
+
+	invalidFile := []byte("package main\n\n//maxcomplexity:many\nfunc main() {\n}\n")
+	if err := AssertMaxComplexity(invalidFile, 10); err == nil {
+		t.Error("Invalid maxcomplexity directive should give an error!")
+	}
+}
+
+func TestComplexityWithoutFunctions(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/packages/doconly/_doc.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(functions) != 0 {
+		t.Errorf("Number of functions should be 0, but are %d!\n", len(functions))
+	}
+}
+
+func TestStmtListComplexity(t *testing.T) {
+	srcFile := "package main\n\nfunc main() {\n" +
+		"\tprintln(x)\n" +
+		"\tif x > 0 {\n\t\tprintln(x)\n\t} else {\n\t\tprintln(x)\n\t}\n" +
+		"\tfor x != 0 {\n\t\tx--\n\t}\n" +
+		"\tfor x != 0 {\n\t\tif x > 0 {\n\t\t\tprintln(x)\n\t\t} else {\n\t\t\tprintln(x)\n\t\t}\n\t}\n" +
+		"\tif x < 0 { x = -x }; for x > 9 { x /= 10 }\n" +
+		"}\n"
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", srcFile, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts := file.Decls[0].(*ast.FuncDecl).Body.List
+	printX, ifStmt, forStmt, nestedStmt := stmts[0], stmts[1], stmts[2], stmts[3]
+
+	testCases := []struct {
+		stmts      []ast.Stmt
+		complexity int
+	}{
+		{[]ast.Stmt{printX}, 1},
+		{[]ast.Stmt{ifStmt}, 2},
+		{[]ast.Stmt{forStmt}, 2},
+		{[]ast.Stmt{nestedStmt}, 3},
+		{[]ast.Stmt{ifStmt, forStmt}, 3},
+		{stmts[4:], 3}, //Statements on a single line.
+	}
+
+	for index, testCase := range testCases {
+		if complexity := StmtListComplexity(testCase.stmts); complexity != testCase.complexity {
+			t.Errorf("Statement list nr. %d should have cyclomatic complexity %d, but has %d!\n", index,
+				testCase.complexity, complexity)
+		}
+	}
+
+	//Statements built by hand have no position.
+	printY := &ast.ExprStmt{X: &ast.CallExpr{Fun: ast.NewIdent("println"), Args: []ast.Expr{ast.NewIdent("y")}}}
+	handBuilt := &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: ast.NewIdent("y"), Op: token.GTR, Y: &ast.BasicLit{Kind: token.INT, Value: "0"}},
+		Body: &ast.BlockStmt{List: []ast.Stmt{printY}},
+		Else: &ast.BlockStmt{List: []ast.Stmt{printY}},
+	}
+	if complexity := StmtListComplexity([]ast.Stmt{handBuilt}); complexity != 2 {
+		t.Errorf("Hand-built statement list should have cyclomatic complexity 2, but has %d!\n", complexity)
+	}
+	if complexity := StmtListComplexity([]ast.Stmt{printY, handBuilt, forStmt}); complexity != 3 {
+		t.Errorf("Hand-built and parsed statement list should have cyclomatic complexity 3, but has %d!\n", complexity)
+	}
+	if complexity := StmtListComplexity(nil); complexity != 1 {
+		t.Errorf("Empty statement list should have cyclomatic complexity 1, but has %d!\n", complexity)
+	}
+}
+
+func TestDeferRecoverComplexity(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_deferrecover.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCyclomaticComplexity, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Calling recover() adds the path where the panic is not recovered, unless an if statement already
+	//branches on its result.
+	correctCyclomaticComplexity := []FunctionComplexity{
+		FunctionComplexity{Name: "main", Complexity: 1},
+		FunctionComplexity{Name: "safeDivide", Complexity: 1},
+		FunctionComplexity{Name: "checkedDivide", Complexity: 1},
+		FunctionComplexity{Name: "guardedDivide", Complexity: 1},
+		FunctionComplexity{Name: "testedDivide", Complexity: 1},
+		FunctionComplexity{Name: "safeDivide$func1", Complexity: 2},
+		FunctionComplexity{Name: "checkedDivide$func1", Complexity: 2},
+		FunctionComplexity{Name: "checkedDivide$func2", Complexity: 1},
+		FunctionComplexity{Name: "guardedDivide$func1", Complexity: 2},
+		FunctionComplexity{Name: "testedDivide$func1", Complexity: 2}, //Result of recover() tested by the next if statement.
+	}
+
+	if err := verifyCyclomaticComplexity(expectedCyclomaticComplexity, correctCyclomaticComplexity); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestComplexityMatchesControlFlowGraph(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_exitpaths.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	complexities, err := ComplexityFromSource(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Several returns are joined in one exit, a recover() adds the exit of the panic not recovered.
+	correctComplexities := map[string]int{"main": 1, "lookup": 3, "guarded": 1, "main$func1": 1, "guarded$func1": 2}
+	if len(functions) != len(correctComplexities) {
+		t.Fatalf("Number of functions should be %d, but are %d!\n", len(correctComplexities), len(functions))
+	}
+	for _, function := range functions {
+		correct := correctComplexities[function.Name]
+		if function.Complexity != correct || complexities[function.Name] != correct {
+			t.Errorf("Function %s should have cyclomatic complexity %d, but has %d from the control-flow graph and %d from the basic-blocks!\n",
+				function.Name, correct, function.Complexity, complexities[function.Name])
+		}
+	}
+}
+
+func TestSwitchComplexityContribution(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_largeswitch.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		switchLine   int
+		contribution int
+	}{
+		{14, 6}, //Switch with default clause, and a case clause with two values.
+		{35, 2}, //Type switch without default clause.
+	}
+
+	for _, testCase := range testCases {
+		contribution, err := SwitchComplexityContribution(srcFile, testCase.switchLine)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contribution != testCase.contribution {
+			t.Errorf("Switch at line %d should contribute %d to complexity, but contributes %d!\n",
+				testCase.switchLine, testCase.contribution, contribution)
+		}
+	}
+
+	if _, err := SwitchComplexityContribution(srcFile, 12); err == nil {
+		t.Error("Line 12 has no switch statement and should give an error!")
+	}
+}
+
+func TestMethodComplexityLookup(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_methods.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		typeName   string
+		line       int
+		complexity int
+	}{
+		{"square", 17, 1}, //Value receiver.
+		{"shape", 21, 3},  //Pointer receiver, method with the same name.
+	}
+
+	for _, testCase := range testCases {
+		method, err := MethodComplexity(srcFile, testCase.typeName, "Area")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if method.Line != testCase.line {
+			t.Errorf("Method %s.Area should be at line %d, but is at line %d!\n", testCase.typeName, testCase.line, method.Line)
+		}
+		if method.Complexity != testCase.complexity {
+			t.Errorf("Method %s.Area should have complexity %d, but has %d!\n", testCase.typeName,
+				testCase.complexity, method.Complexity)
+		}
+	}
+
+	if _, err := MethodComplexity(srcFile, "circle", "Area"); err == nil {
+		t.Error("Type circle has no method Area and should give an error!")
+	}
+	if _, err := MethodComplexity(srcFile, "", "main"); err == nil {
+		t.Error("Function main is no method and should give an error!")
+	}
+}
+
+func TestComplexityFromSource(t *testing.T) {
+	testCases := []struct {
+		srcPath    string
+		complexity map[string]int
+	}{
+		{"./testcode/_gcd.go", map[string]int{"gcd": 2, "main": 1}},
+		{"./testcode/_switch.go", map[string]int{"main": 6}},
+		{"./testcode/_switcher.go", map[string]int{"main": 1, "monthNumberToString": 13}},
+		{"./bblock/testcode/_loopbreak.go", map[string]int{"count": 2, "main": 1}},
+		{"./bblock/testcode/_labeledcase.go", map[string]int{"wait": 2, "skip": 4, "main": 1}},
+		{"./bblock/testcode/_typeswitchguard.go", map[string]int{"describe": 5}},
+		{"./bblock/testcode/_caserange.go", map[string]int{"total": 3, "main": 1}},
+		{"./testcode/_rangeloops.go", map[string]int{"main": 1, "sum": 3}},
+	}
+
+	for _, testCase := range testCases {
+		srcFile, err := ioutil.ReadFile(testCase.srcPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		complexities, err := ComplexityFromSource(srcFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(complexities) != len(testCase.complexity) {
+			t.Errorf("Number of functions in %s should be %d, but are %d!\n", testCase.srcPath,
+				len(testCase.complexity), len(complexities))
+		}
+		for name, complexity := range testCase.complexity {
+			if complexities[name] != complexity {
+				t.Errorf("Function %s in %s should have cyclomatic complexity %d, but has %d!\n", name,
+					testCase.srcPath, complexity, complexities[name])
+			}
+		}
+
+		//Complexity from the basic-blocks equals complexity from the control-flow graph.
+		functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, function := range functions {
+			if complexities[function.Name] != function.Complexity {
+				t.Errorf("Function %s in %s should have the same complexity %d from basic-blocks as from its "+
+					"control-flow graph, but has %d!\n", function.Name, testCase.srcPath, function.Complexity,
+					complexities[function.Name])
+			}
+		}
+	}
+}
+
+func TestComplexityWithBooleans(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_booleans.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	structural, err := ComplexityFromSource(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	complexities, err := ComplexityWithBooleans(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Boolean operators added to the structural complexity of each function.
+	correctOperators := map[string]int{
+		"single":     0, //if a
+		"triple":     2, //if a && b && c
+		"nested":     3, //for ...; i < 3 && (a || b && c); ...
+		"main":       0, //Operators outside conditions are not decisions.
+		"main$func1": 1, //if a || b
+	}
+	if len(complexities) != len(correctOperators) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(correctOperators), len(complexities))
+	}
+	for name, operators := range correctOperators {
+		if complexities[name] != structural[name]+operators {
+			t.Errorf("Function %s should have extended complexity %d, but has %d!\n", name, structural[name]+operators,
+				complexities[name])
+		}
+	}
+	if structural["single"] != 2 || structural["triple"] != 2 {
+		t.Errorf("Functions single and triple should have structural complexity 2, but have %d and %d!\n",
+			structural["single"], structural["triple"])
+	}
+}
+
+func TestSelectComplexity(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_selectkinds.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	complexities, err := ComplexityFromSource(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctComplexities := map[string]int{
+		"blocking":    2, //Blocks until one of two cases can run, never skipping the select.
+		"polling":     3, //Two cases, or the default clause when neither can run.
+		"defaultOnly": 1, //The default clause always runs.
+		"forever":     1, //Blocks forever.
+		"receiveLoop": 2, //The loop is left by the default clause only.
+		"main":        1,
+	}
+	for name, complexity := range correctComplexities {
+		if complexities[name] != complexity {
+			t.Errorf("Function %s should have cyclomatic complexity %d, but has %d!\n", name, complexity, complexities[name])
+		}
+	}
+}
+
+func TestOneLinerComplexity(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_oneliners.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCyclomaticComplexity, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Functions on a single line get the blocks of their statements, and the decisions in them.
+	correctCyclomaticComplexity := []FunctionComplexity{
+		FunctionComplexity{Name: "g", Complexity: 1},
+		FunctionComplexity{Name: "h", Complexity: 1},
+		FunctionComplexity{Name: "sum", Complexity: 1},
+		FunctionComplexity{Name: "max", Complexity: 1},
+		FunctionComplexity{Name: "double", Complexity: 1},
+		FunctionComplexity{Name: "show", Complexity: 1},
+		FunctionComplexity{Name: "noop", Complexity: 1},
+		FunctionComplexity{Name: "apply", Complexity: 1},
+		FunctionComplexity{Name: "abs", Complexity: 2},
+		FunctionComplexity{Name: "pick", Complexity: 3},
+		FunctionComplexity{Name: "both", Complexity: 1},
+		FunctionComplexity{Name: "main", Complexity: 1},
+		FunctionComplexity{Name: "apply$func1", Complexity: 1},
+	}
+
+	if err := verifyCyclomaticComplexity(expectedCyclomaticComplexity, correctCyclomaticComplexity); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUntestedComplexFunctions(t *testing.T) {
+	results := []FunctionComplexity{
+		FunctionComplexity{Name: "parse", Complexity: 12},
+		FunctionComplexity{Name: "main", Complexity: 1},
+		FunctionComplexity{Name: "render", Complexity: 8},
+		FunctionComplexity{Name: "validate", Complexity: 5},
+		FunctionComplexity{Name: "dispatch", Complexity: 15},
+	}
+	covered := map[string]bool{"dispatch": true, "main": true, "validate": false}
+
+	correctCyclomaticComplexity := []FunctionComplexity{
+		FunctionComplexity{Name: "parse", Complexity: 12},
+		FunctionComplexity{Name: "render", Complexity: 8},
+		FunctionComplexity{Name: "validate", Complexity: 5},
+	}
+
+	untested := UntestedComplexFunctions(results, covered, 5)
+	expectedCyclomaticComplexity := make([]*FunctionComplexity, len(untested))
+	for index := range untested {
+		expectedCyclomaticComplexity[index] = &untested[index]
+	}
+	if err := verifyCyclomaticComplexity(expectedCyclomaticComplexity, correctCyclomaticComplexity); err != nil {
+		t.Error(err)
+	}
+
+	if untested := UntestedComplexFunctions(results, covered, 20); len(untested) != 0 {
+		t.Errorf("Number of untested functions with complexity 20 or more should be %d, but are %d!\n", 0, len(untested))
+	}
+}
+
+func TestBudget(t *testing.T) {
+	results := []FunctionComplexity{
+		FunctionComplexity{Name: "parse", File: "parse.go", Complexity: 12},
+		FunctionComplexity{Name: "main", Complexity: 1},
+		FunctionComplexity{Name: "render", Complexity: 10},
+		FunctionComplexity{Name: "parse", File: "legacy.go", Complexity: 4},
+	}
+
+	correctBudgets := map[string]int{"parse": -2, "main": 9, "render": 0}
+	if budgets := Budget(results, 10); !reflect.DeepEqual(budgets, correctBudgets) {
+		t.Errorf("Budgets with limit 10 should be %v, and not %v!\n", correctBudgets, budgets)
+	}
+
+	if budgets := Budget(nil, 10); len(budgets) != 0 {
+		t.Errorf("Number of budgets without functions should be 0, but are %d!\n", len(budgets))
+	}
+}
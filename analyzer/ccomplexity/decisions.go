@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/ast"
+	"go/token"
+)
+
+// DecisionKind is the kind of statement making a decision.
+type DecisionKind int
+
+//Decision kinds.
+const (
+	IF_DECISION    DecisionKind = iota //If statement.
+	FOR_DECISION                       //For loop, with or without condition.
+	RANGE_DECISION                     //For loop with range clause.
+	CASE_DECISION                      //Case clause other than the default clause.
+	COMM_DECISION                      //Comm clause other than the default clause.
+)
+
+var decisionKindStrings = [...]string{
+	IF_DECISION:    "IF_DECISION",
+	FOR_DECISION:   "FOR_DECISION",
+	RANGE_DECISION: "RANGE_DECISION",
+	CASE_DECISION:  "CASE_DECISION",
+	COMM_DECISION:  "COMM_DECISION",
+}
+
+func (kind DecisionKind) String() string {
+	return decisionKindStrings[kind]
+}
+
+// Decision describes a decision in a function.
+type Decision struct {
+	Function  string       //Name of function containing the decision, receiver-qualified for methods.
+	Kind      DecisionKind //Kind of decision.
+	Line      int          //Line number of the decision statement or clause.
+	Condition string       //Source text of the condition, range expression, case expressions or comm statement.
+	Depth     int          //Number of if, loop, switch and select statements enclosing the decision.
+}
+
+// EachDecision calls fn for every decision in the functions in srcFile in source order, the
+// decisions counted by DecisionCommentCoverage. Case and comm clauses have the depth of their
+// switch or select statement, and the arms of an else-if chain the depth of the first if.
+// The condition of a for loop without condition is empty. A source failing to parse has no
+// decisions.
+func EachDecision(srcFile []byte, fn func(decision Decision)) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return
+	}
+
+	//sourceText returns the source text from the start of the first to the end of the last node.
+	sourceText := func(first, last ast.Node) string {
+		if first == nil || last == nil {
+			return ""
+		}
+		return string(srcFile[fileSet.Position(first.Pos()).Offset:fileSet.Position(last.End()).Offset])
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		report := func(kind DecisionKind, position token.Pos, condition string, depth int) {
+			fn(Decision{
+				Function:  bblock.QualifiedFuncName(funcDecl),
+				Kind:      kind,
+				Line:      fileSet.Position(position).Line,
+				Condition: condition,
+				Depth:     depth,
+			})
+		}
+
+		var walk func(node ast.Node, depth int)
+		walk = func(node ast.Node, depth int) {
+			ast.Inspect(node, func(node ast.Node) bool {
+				switch t := node.(type) {
+				case *ast.IfStmt:
+					report(IF_DECISION, t.Pos(), sourceText(t.Cond, t.Cond), depth)
+					walk(t.Body, depth+1)
+					if elseIf, ok := t.Else.(*ast.IfStmt); ok {
+						walk(elseIf, depth)
+					} else if t.Else != nil {
+						walk(t.Else, depth+1)
+					}
+				case *ast.ForStmt:
+					report(FOR_DECISION, t.Pos(), sourceText(t.Cond, t.Cond), depth)
+					walk(t.Body, depth+1)
+				case *ast.RangeStmt:
+					report(RANGE_DECISION, t.Pos(), sourceText(t.X, t.X), depth)
+					walk(t.Body, depth+1)
+				case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+					return true
+				case *ast.CaseClause:
+					if t.List != nil {
+						report(CASE_DECISION, t.Pos(), sourceText(t.List[0], t.List[len(t.List)-1]), depth)
+					}
+					for _, stmt := range t.Body {
+						walk(stmt, depth+1)
+					}
+				case *ast.CommClause:
+					if t.Comm != nil {
+						report(COMM_DECISION, t.Pos(), sourceText(t.Comm, t.Comm), depth)
+					}
+					for _, stmt := range t.Body {
+						walk(stmt, depth+1)
+					}
+				default:
+					return true
+				}
+				return false
+			})
+		}
+		walk(funcDecl.Body, 0)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestEachDecision(t *testing.T) {
+	testCases := []struct {
+		sourceFile string
+		decisions  []Decision
+	}{
+		{"./testcode/_switch.go", []Decision{
+			{Function: "main", Kind: CASE_DECISION, Line: 14, Condition: "0"},
+			{Function: "main", Kind: CASE_DECISION, Line: 16, Condition: "1"},
+			{Function: "main", Kind: CASE_DECISION, Line: 19, Condition: "2"},
+			{Function: "main", Kind: CASE_DECISION, Line: 21, Condition: "3"},
+			{Function: "main", Kind: CASE_DECISION, Line: 23, Condition: "4"},
+		}},
+		{"./testcode/_arrowcode.go", []Decision{
+			{Function: "arrow", Kind: IF_DECISION, Line: 15, Condition: "len(values) > 0"},
+			{Function: "arrow", Kind: RANGE_DECISION, Line: 16, Condition: "values", Depth: 1},
+			{Function: "arrow", Kind: IF_DECISION, Line: 17, Condition: "value > 0", Depth: 2},
+			{Function: "arrow", Kind: CASE_DECISION, Line: 19, Condition: "value > limit", Depth: 3},
+			{Function: "stepped", Kind: IF_DECISION, Line: 30, Condition: "n > 0"},
+			{Function: "stepped", Kind: IF_DECISION, Line: 31, Condition: "n > 1", Depth: 1},
+			{Function: "stepped", Kind: FOR_DECISION, Line: 34, Condition: "i < n", Depth: 1},
+			{Function: "stepped", Kind: IF_DECISION, Line: 35, Condition: "i%2 == 0", Depth: 2},
+			{Function: "stepped", Kind: IF_DECISION, Line: 36, Condition: "i > 2", Depth: 3},
+			{Function: "shallow", Kind: IF_DECISION, Line: 47, Condition: "n < 0"},
+			{Function: "shallow", Kind: IF_DECISION, Line: 49, Condition: "n == 0"},
+			{Function: "shallow", Kind: IF_DECISION, Line: 51, Condition: "n < 10"},
+		}},
+	}
+
+	for _, testCase := range testCases {
+		srcFile, err := ioutil.ReadFile(testCase.sourceFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decisions []Decision
+		EachDecision(srcFile, func(decision Decision) {
+			decisions = append(decisions, decision)
+		})
+
+		if len(decisions) != len(testCase.decisions) {
+			t.Fatalf("Number of decisions in %s should be %d, but are %d!\n", testCase.sourceFile,
+				len(testCase.decisions), len(decisions))
+		}
+		for index, decision := range decisions {
+			if decision != testCase.decisions[index] {
+				t.Errorf("Decision nr. %d in %s should be %+v, and not %+v!\n", index, testCase.sourceFile,
+					testCase.decisions[index], decision)
+			}
+		}
+	}
+
+	//A source failing to parse has no decisions.
+	EachDecision([]byte("package main\n\nfunc main() {\n\tif true {\n"), func(decision Decision) {
+		t.Errorf("Source failing to parse should have no decisions, but has %+v!\n", decision)
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/ast"
+)
+
+// BranchStatementRatio returns the number of decisions divided by the number of statements of
+// each function in srcFile, keyed by receiver-qualified function name. Decisions are counted as by
+// DecisionCommentCoverage, and statements include the control structures themselves, but not
+// blocks, case and comm clauses or empty statements. A high ratio marks a function complex for
+// its dense branching rather than for its size. Functions without statements are left out.
+func BranchStatementRatio(srcFile []byte) (map[string]float64, error) {
+	_, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ratios := map[string]float64{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		decisions, statements := 0, 0
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			if isDecision(node) {
+				decisions++
+			}
+			switch node.(type) {
+			case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause, *ast.EmptyStmt:
+			case ast.Stmt:
+				statements++
+			}
+			return true
+		})
+		if statements > 0 {
+			ratios[bblock.QualifiedFuncName(funcDecl)] = float64(decisions) / float64(statements)
+		}
+	}
+	return ratios, nil
+}
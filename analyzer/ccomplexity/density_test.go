@@ -0,0 +1,30 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestBranchStatementRatio(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_density.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ratios, err := BranchStatementRatio(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctRatios := map[string]float64{
+		"straight": 0,     //No decisions in 4 statements.
+		"dense":    0.5,   //If and 2 case clauses in 6 statements: if, return, switch and 3 returns.
+		"long":     0.125, //Range loop in 8 statements.
+	}
+	if !reflect.DeepEqual(ratios, correctRatios) {
+		t.Errorf("Branch statement ratios should be %v, and not %v!\n", correctRatios, ratios)
+	}
+}
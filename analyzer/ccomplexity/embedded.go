@@ -0,0 +1,36 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// AnalyzeEmbeddedGo computes cyclomatic complexity for each function in the Go source held
+// by literal, a Go string literal as written in a code generator, quotes included. Escape
+// sequences in interpreted literals are decoded before the source is parsed. Source without
+// a package clause, such as a single function, is analyzed as part of package main, keeping
+// the line numbers of the literal.
+func AnalyzeEmbeddedGo(literal string) ([]FunctionComplexity, error) {
+	src, err := strconv.Unquote(literal)
+	if err != nil {
+		return nil, err
+	}
+	srcFile := []byte(src)
+	if _, err := parser.ParseFile(token.NewFileSet(), "", srcFile, parser.PackageClauseOnly); err != nil {
+		srcFile = append([]byte("package main; "), srcFile...)
+	}
+
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]FunctionComplexity, len(functions))
+	for index, function := range functions {
+		results[index] = *function
+	}
+	return results, nil
+}
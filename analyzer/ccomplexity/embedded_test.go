@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import "testing"
+
+func TestAnalyzeEmbeddedGo(t *testing.T) {
+	testCases := []struct {
+		literal   string
+		functions []FunctionComplexity
+	}{
+		//Function in a raw string literal, as embedded by a code generator.
+		{"`" + `
+func sign(x int) int {
+	if x < 0 {
+		return -1
+	} else if x > 0 {
+		return 1
+	}
+	return 0
+}
+` + "`", []FunctionComplexity{
+			FunctionComplexity{Name: "sign", Line: 2, Complexity: 3},
+		}},
+		//Whole file in an interpreted string literal, with escaped quotes, tabs and newlines.
+		{`"package gen\n\nfunc greet(name string) string {\n\tif name == \"\" {\n\t\tname = \"world\"\n\t}\n\treturn \"hello \" + name\n}\n"`,
+			[]FunctionComplexity{
+				FunctionComplexity{Name: "greet", Line: 3, Complexity: 2},
+			}},
+	}
+
+	for _, testCase := range testCases {
+		functions, err := AnalyzeEmbeddedGo(testCase.literal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(functions) != len(testCase.functions) {
+			t.Fatalf("Number of functions should be %d, but are %d!\n", len(testCase.functions), len(functions))
+		}
+		for index, function := range functions {
+			correctFunction := testCase.functions[index]
+			if function.Name != correctFunction.Name || function.Line != correctFunction.Line ||
+				function.Complexity != correctFunction.Complexity {
+				t.Errorf("Function should be %s at line %d with complexity %d, and not %s at line %d with complexity %d!\n",
+					correctFunction.Name, correctFunction.Line, correctFunction.Complexity,
+					function.Name, function.Line, function.Complexity)
+			}
+		}
+	}
+}
+
+func TestAnalyzeEmbeddedGoInvalidLiteral(t *testing.T) {
+	for _, literal := range []string{"func main() {}", `"func main() {}`, `"\q"`} {
+		if _, err := AnalyzeEmbeddedGo(literal); err == nil {
+			t.Errorf("Analyzing %s should fail, as it is not a valid string literal!\n", literal)
+		}
+	}
+}
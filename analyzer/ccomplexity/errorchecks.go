@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// isErrorCheck reports whether the if statement is a standard error check, if err != nil
+// without an else branch.
+func isErrorCheck(ifStmt *ast.IfStmt) bool {
+	cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ || ifStmt.Else != nil {
+		return false
+	}
+	x, xOk := cond.X.(*ast.Ident)
+	y, yOk := cond.Y.(*ast.Ident)
+	return xOk && yOk && x.Name == "err" && y.Name == "nil"
+}
+
+// discountErrorChecks returns a copy of srcFile with the standard error checks removed, so
+// their branches do not add to the complexity. The checks are blanked out keeping the line
+// breaks, so the functions keep their line numbers, and the init statement of a check as in
+// if _, err := f(); err != nil is kept. A check in an else if arm is rewritten to an empty
+// else block, keeping the source parseable.
+func discountErrorChecks(srcFile []byte) ([]byte, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	discounted := append([]byte(nil), srcFile...)
+	blank := func(from, to token.Pos) {
+		for offset := fileSet.Position(from).Offset; offset < fileSet.Position(to).Offset; offset++ {
+			if discounted[offset] != '\n' {
+				discounted[offset] = ' '
+			}
+		}
+	}
+	elseArms := map[*ast.IfStmt]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		ifStmt, ok := node.(*ast.IfStmt)
+		if !ok {
+			return true
+		}
+		if elseIf, ok := ifStmt.Else.(*ast.IfStmt); ok {
+			elseArms[elseIf] = true
+		}
+		if !isErrorCheck(ifStmt) {
+			return true
+		}
+		if ifStmt.Init != nil {
+			blank(ifStmt.Pos(), ifStmt.Init.Pos())
+			blank(ifStmt.Init.End(), ifStmt.End())
+		} else {
+			blank(ifStmt.Pos(), ifStmt.End())
+		}
+		if elseArms[ifStmt] {
+			discounted[fileSet.Position(ifStmt.Pos()).Offset] = '{'
+			discounted[fileSet.Position(ifStmt.End()).Offset-1] = '}'
+		}
+		return false
+	})
+	return discounted, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeDirDiscountingErrors(t *testing.T) {
+	functions, err := NewAnalyzer().AnalyzeDir("./testcode/packages/errorchecks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	discountedFunctions, err := AnalyzeDirDiscountingErrors("./testcode/packages/errorchecks")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//A check in an else if arm must leave the discounted source parseable.
+	analyzer := NewAnalyzer(WithErrorCheckDiscount(true))
+	if _, err := analyzer.AnalyzeDir("./testcode/packages/errorchecks"); err != nil {
+		t.Fatal(err)
+	}
+	if skipped := analyzer.SkippedFiles(); len(skipped) != 0 {
+		t.Errorf("No files should be skipped discounting error checks, but skipped %v!\n", skipped)
+	}
+
+	testCases := []struct {
+		name                   string
+		line                   int
+		complexity, discounted int
+	}{
+		{"parseAll", 8, 3, 2},
+		{"sum", 20, 3, 2},
+		{"Open", 18, 5, 2},
+		{"(*Store).Save", 41, 4, 2},
+		{"(*Store).Level", 55, 3, 3}, //No error checks.
+		{"(*Store).Load", 66, 3, 2},  //Error check in an else if arm.
+	}
+	if len(functions) != len(testCases) || len(discountedFunctions) != len(testCases) {
+		t.Fatalf("Number of functions should be %d, but are %d and %d discounted!\n", len(testCases), len(functions),
+			len(discountedFunctions))
+	}
+	for index, testCase := range testCases {
+		function, discounted := functions[index], discountedFunctions[index]
+		if function.Name != testCase.name || function.Complexity != testCase.complexity {
+			t.Errorf("Function %d should be %s with complexity %d, and not %s with complexity %d!\n", index,
+				testCase.name, testCase.complexity, function.Name, function.Complexity)
+		}
+		if discounted.Name != testCase.name || discounted.Line != testCase.line || discounted.Complexity != testCase.discounted {
+			t.Errorf("Discounted function %d should be %s at line %d with complexity %d, and not %s at line %d with complexity %d!\n",
+				index, testCase.name, testCase.line, testCase.discounted, discounted.Name, discounted.Line, discounted.Complexity)
+		}
+	}
+}
+
+func TestAnalyzeDirDiscountingErrorsWithoutBodies(t *testing.T) {
+	//Functions without body and files without functions are left out.
+	functions, err := AnalyzeDirDiscountingErrors("./testcode/packages/linkname")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "since", Complexity: 2},
+		FunctionComplexity{Name: "check", Complexity: 2},
+	}
+	if len(functions) != len(correctFunctions) {
+		t.Fatalf("Number of functions should be %d, but are %d!\n", len(correctFunctions), len(functions))
+	}
+	for index, correct := range correctFunctions {
+		if functions[index].Name != correct.Name || functions[index].Complexity != correct.Complexity {
+			t.Errorf("Function %d should be %s with complexity %d, and not %s with complexity %d!\n", index,
+				correct.Name, correct.Complexity, functions[index].Name, functions[index].Complexity)
+		}
+	}
+}
+
+func TestDiscountErrorChecks(t *testing.T) {
+	srcFile := []byte("package main\n\nfunc main() {\n\tif err := run(); err != nil {\n\t\tpanic(err)\n\t}\n}\n")
+
+	discounted, err := discountErrorChecks(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//The init statement is kept, and the line breaks are kept for the following line numbers.
+	if !strings.Contains(string(discounted), "err := run()") || strings.Contains(string(discounted), "panic") {
+		t.Errorf("Discounted source should keep the init statement and not the check, but is %q!\n", discounted)
+	}
+	if lines, correctLines := strings.Count(string(discounted), "\n"), strings.Count(string(srcFile), "\n"); lines != correctLines {
+		t.Errorf("Number of lines in discounted source should be %d, but are %d!\n", correctLines, lines)
+	}
+}
+
+func TestDiscountElseIfErrorChecks(t *testing.T) {
+	srcFile := []byte("package main\n\nfunc main() {\n\tif ok {\n\t\treturn\n\t} else if _, err := run(); err != nil {\n\t\tpanic(err)\n\t}\n}\n")
+
+	discounted, err := discountErrorChecks(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//The else if arm becomes an else block keeping the init statement.
+	if _, _, err := parseSourceCode(discounted); err != nil {
+		t.Errorf("Discounted source should parse, but got error: %s\n", err)
+	}
+	if !strings.Contains(string(discounted), "_, err := run()") || strings.Contains(string(discounted), "panic") {
+		t.Errorf("Discounted source should keep the init statement and not the check, but is %q!\n", discounted)
+	}
+}
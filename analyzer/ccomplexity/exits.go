@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// ExitStats counts the ways a function terminates.
+type ExitStats struct {
+	Returns      int  //Return statements, and the end of the body if control can reach it.
+	Panics       int  //Calls to panic.
+	Exits        int  //Calls to os.Exit, and to log.Fatal, log.Fatalf and log.Fatalln calling it.
+	LoopsForever bool //Function has a for loop without condition, which may never be left.
+}
+
+// ExitProfile returns how each function in srcFile terminates, keyed by receiver-qualified function name.
+// Function literals are left out, as their returns and panics do not leave the enclosing
+// function before the literal is called.
+func ExitProfile(srcFile []byte) (map[string]ExitStats, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	loops, err := Loops(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	imports := importNames(file)
+
+	profile := map[string]ExitStats{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		stats := ExitStats{}
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				stats.Returns++
+			case *ast.CallExpr:
+				if isPanicCall(t) {
+					stats.Panics++
+				} else if isExitCall(t, imports) {
+					stats.Exits++
+				}
+			}
+			return true
+		})
+		if !isTerminating(funcDecl.Body, "", imports) {
+			stats.Returns++ //Control reaches the end of the body.
+		}
+
+		startLine := fileSet.Position(funcDecl.Pos()).Line
+		endLine := fileSet.Position(funcDecl.End()).Line
+		for _, loop := range loops {
+			if loop.Kind == INFINITE_LOOP && loop.Line >= startLine && loop.Line <= endLine {
+				stats.LoopsForever = true
+			}
+		}
+		profile[bblock.QualifiedFuncName(funcDecl)] = stats
+	}
+	return profile, nil
+}
+
+// importNames returns the names packages imported by file are referred to by, keyed by
+// import path.
+func importNames(file *ast.File) map[string]string {
+	names := map[string]string{}
+	for _, importSpec := range file.Imports {
+		path, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			continue
+		}
+		names[path] = path
+		if importSpec.Name != nil {
+			names[path] = importSpec.Name.Name
+		}
+	}
+	return names
+}
+
+// isPanicCall reports whether call is a call to the built-in panic.
+func isPanicCall(call *ast.CallExpr) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == "panic"
+}
+
+// isExitCall reports whether call is a call to os.Exit, or to log.Fatal, log.Fatalf or
+// log.Fatalln, with packages referred to by the names in imports.
+func isExitCall(call *ast.CallExpr, imports map[string]string) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	switch selector.Sel.Name {
+	case "Exit":
+		return imports["os"] != "" && pkg.Name == imports["os"]
+	case "Fatal", "Fatalf", "Fatalln":
+		return imports["log"] != "" && pkg.Name == imports["log"]
+	}
+	return false
+}
+
+// isTerminating reports whether stmt, labeled label if not empty, is a terminating statement,
+// ending in a return, goto, panic or exit call, or an infinite loop, so control does not reach
+// the statement following it. Switch and select statements terminate if none of their clauses
+// break and every clause terminates, with a default clause in switch statements.
+func isTerminating(stmt ast.Stmt, label string, imports map[string]string) bool {
+	switch t := stmt.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return t.Tok == token.GOTO
+	case *ast.ExprStmt:
+		call, ok := t.X.(*ast.CallExpr)
+		return ok && (isPanicCall(call) || isExitCall(call, imports))
+	case *ast.LabeledStmt:
+		return isTerminating(t.Stmt, t.Label.Name, imports)
+	case *ast.BlockStmt:
+		return len(t.List) > 0 && isTerminating(t.List[len(t.List)-1], "", imports)
+	case *ast.IfStmt:
+		return t.Else != nil && isTerminating(t.Body, "", imports) && isTerminating(t.Else, "", imports)
+	case *ast.ForStmt:
+		return t.Cond == nil && !hasBreak(t.Body, label)
+	case *ast.SwitchStmt:
+		return clausesTerminate(t.Body, label, true, imports)
+	case *ast.TypeSwitchStmt:
+		return clausesTerminate(t.Body, label, true, imports)
+	case *ast.SelectStmt:
+		return clausesTerminate(t.Body, label, false, imports)
+	}
+	return false
+}
+
+// clausesTerminate reports whether the clauses in body of a switch or select statement
+// labeled label all terminate without breaking, with a default clause if needsDefault.
+// Case clauses may also end falling through.
+func clausesTerminate(body *ast.BlockStmt, label string, needsDefault bool, imports map[string]string) bool {
+	hasDefault := false
+	for _, stmt := range body.List {
+		var clauseBody []ast.Stmt
+		switch t := stmt.(type) {
+		case *ast.CaseClause:
+			hasDefault = hasDefault || t.List == nil
+			clauseBody = t.Body
+		case *ast.CommClause:
+			clauseBody = t.Body
+		}
+		block := &ast.BlockStmt{List: clauseBody}
+		if hasBreak(block, label) {
+			return false
+		}
+		if len(clauseBody) > 0 {
+			if branch, ok := clauseBody[len(clauseBody)-1].(*ast.BranchStmt); ok && branch.Tok == token.FALLTHROUGH {
+				continue
+			}
+		}
+		if !isTerminating(block, "", imports) {
+			return false
+		}
+	}
+	return hasDefault || !needsDefault
+}
+
+// hasBreak reports whether body contains a break leaving the statement labeled label that
+// body belongs to, an unlabeled break outside nested loops, switches and selects, or a break
+// with the label. Function literals are skipped.
+func hasBreak(body *ast.BlockStmt, label string) bool {
+	found := false
+	var inspect func(node ast.Node, nested bool)
+	inspect = func(node ast.Node, nested bool) {
+		ast.Inspect(node, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if !nested {
+					inspect(node, true) //Unlabeled breaks inside leave the nested statement.
+					return false
+				}
+			case *ast.BranchStmt:
+				if t.Tok == token.BREAK && (t.Label == nil && !nested || t.Label != nil && t.Label.Name == label) {
+					found = true
+				}
+			}
+			return !found
+		})
+	}
+	inspect(body, false)
+	return found
+}
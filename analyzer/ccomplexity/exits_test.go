@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestExitProfile(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_exits.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := ExitProfile(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctProfile := map[string]ExitStats{
+		"parse": {Returns: 2, Panics: 1},
+		"run":   {Returns: 1, Exits: 2},           //Returns at the end of the body.
+		"serve": {Returns: 1, LoopsForever: true}, //Infinite loop, left by return only.
+		"drain": {Returns: 1, LoopsForever: true}, //Infinite loop, left by break to the end of the body.
+		"check": {Returns: 1, Panics: 1},          //Panic in the deferred function literal is left out.
+		"sign":  {Returns: 3},                     //Switch with default returning in every clause.
+		"main":  {Returns: 1},
+	}
+	if len(profile) != len(correctProfile) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(correctProfile), len(profile))
+	}
+	for function, correctStats := range correctProfile {
+		if stats := profile[function]; stats != correctStats {
+			t.Errorf("Exit profile of %s should be %+v, and not %+v!\n", function, correctStats, stats)
+		}
+	}
+}
+
+func TestExitProfileMethods(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./bblock/testcode/_receivers.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := ExitProfile(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctProfile := map[string]ExitStats{
+		"celsius.String":  {Returns: 2},
+		"(*point).String": {Returns: 1},
+		"(*point).Reset":  {Returns: 1}, //Returns at the end of the body.
+		"String":          {Returns: 1},
+		"main":            {Returns: 1},
+	}
+	if len(profile) != len(correctProfile) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(correctProfile), len(profile))
+	}
+	for function, correctStats := range correctProfile {
+		if stats := profile[function]; stats != correctStats {
+			t.Errorf("Exit profile of %s should be %+v, and not %+v!\n", function, correctStats, stats)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// analyzeFile computes cyclomatic complexity for each function in the Go source file at srcPath.
+func analyzeFile(srcPath string) ([]*FunctionComplexity, error) {
+	srcFile, err := ioutil.ReadFile(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	return analyzeSource(srcPath, srcFile)
+}
+
+// analyzeSource computes cyclomatic complexity for each function in srcFile, read from srcPath.
+func analyzeSource(srcPath string, srcFile []byte) ([]*FunctionComplexity, error) {
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, function := range functions {
+		function.File = srcPath
+	}
+	return functions, nil
+}
+
+// getGoFiles returns the sorted paths of Go source files in dir, not searching
+// subdirectories. Test files are skipped.
+func getGoFiles(dir string) (goFiles []string, err error) {
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if fileInfo.Mode().IsRegular() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			goFiles = append(goFiles, filepath.Join(dir, name))
+		}
+	}
+	return goFiles, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import "regexp"
+
+// generatedHeader matches the standard comment marking generated Go source, see
+// https://golang.org/s/generatedcode.
+var generatedHeader = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// generatedCodeMarkers match code commonly emitted by the protobuf and gRPC code generators.
+var generatedCodeMarkers = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^// source: \S+\.proto$`),
+	regexp.MustCompile(`(?m)^// versions:$`),
+	regexp.MustCompile(`\bprotoimpl\.`),
+	regexp.MustCompile(`\bXXX_(unrecognized|sizecache|NoUnkeyedLiteral)\b`),
+	regexp.MustCompile(`\bfileDescriptor_[0-9a-f]+\b`),
+	regexp.MustCompile(`\bgrpc\.ServiceDesc\{`),
+	regexp.MustCompile(`\bmustEmbedUnimplemented\w+Server\(\)`),
+}
+
+// generatedMarkerThreshold is the number of distinct generated code markers making a
+// file without the standard header count as generated. Handwritten code may use one.
+const generatedMarkerThreshold = 2
+
+// IsGeneratedCode reports whether srcFile is generated code, either having the standard
+// "// Code generated ... DO NOT EDIT." comment, or containing code typical of generated
+// protobuf and gRPC code, such as file descriptors and service descriptions.
+func IsGeneratedCode(srcFile []byte) bool {
+	if generatedHeader.Match(srcFile) {
+		return true
+	}
+	markers := 0
+	for _, marker := range generatedCodeMarkers {
+		if marker.Match(srcFile) {
+			markers++
+		}
+	}
+	return markers >= generatedMarkerThreshold
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestIsGeneratedCode(t *testing.T) {
+	testCases := []struct {
+		srcPath   string
+		generated bool
+	}{
+		{"./testcode/packages/generated/_greeter.pb.go", true}, //Protobuf code without the standard header.
+		{"./testcode/packages/generated/_stringer.go", true},   //Standard header.
+		{"./testcode/packages/generated/_server.go", false},    //Handwritten, with a single generated code marker.
+		{"./testcode/_gcd.go", false},
+	}
+
+	for _, testCase := range testCases {
+		srcFile, err := ioutil.ReadFile(testCase.srcPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if generated := IsGeneratedCode(srcFile); generated != testCase.generated {
+			t.Errorf("File %s should be generated code (%t), but is (%t)!\n", testCase.srcPath, testCase.generated,
+				generated)
+		}
+	}
+}
+
+func TestAnalyzerGeneratedFiles(t *testing.T) {
+	//Generated files are skipped by default.
+	functions, err := NewAnalyzer().AnalyzeDir("./testcode/packages/generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "(*server).mustEmbedUnimplementedGreeterServer", Complexity: 1},
+		FunctionComplexity{Name: "greeting", Complexity: 2},
+	}
+	if err := verifyCyclomaticComplexity(functions, correctFunctions); err != nil {
+		t.Error(err)
+	}
+
+	functions, err = NewAnalyzer(WithGeneratedFiles(true)).AnalyzeDir("./testcode/packages/generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions = []FunctionComplexity{
+		FunctionComplexity{Name: "(*HelloRequest).ProtoReflect", Complexity: 1},
+		FunctionComplexity{Name: "file_greeter_proto_exporter", Complexity: 4},
+		FunctionComplexity{Name: "(*server).mustEmbedUnimplementedGreeterServer", Complexity: 1},
+		FunctionComplexity{Name: "greeting", Complexity: 2},
+		FunctionComplexity{Name: "Color.String", Complexity: 3},
+	}
+	if err := verifyCyclomaticComplexity(functions, correctFunctions); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"go/version"
+	"regexp"
+	"strings"
+)
+
+// versionError matches the type checker errors of language features newer than the checked Go version.
+var versionError = regexp.MustCompile(`requires go1\.\d+ or later`)
+
+// checkGoVersion type-checks srcFile, read from srcPath, against Go version goVersion, given
+// as in go.mod ("1.22") or with the go prefix ("go1.22"), and returns an error if the source
+// uses language features newer than goVersion, such as range-over-func before go1.23. Other
+// type errors are ignored, as imports are not resolved and the file is checked by itself.
+func checkGoVersion(srcPath string, srcFile []byte, goVersion string) error {
+	if !strings.HasPrefix(goVersion, "go") {
+		goVersion = "go" + goVersion
+	}
+	if !version.IsValid(goVersion) {
+		return fmt.Errorf("invalid Go version %q", goVersion)
+	}
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, srcPath, srcFile, 0)
+	if err != nil {
+		return err
+	}
+
+	var versionErr error
+	config := types.Config{
+		GoVersion: goVersion,
+		Error: func(err error) {
+			if versionErr == nil && versionError.MatchString(err.Error()) {
+				versionErr = err
+			}
+		},
+	}
+	config.Check(file.Name.Name, fileSet, []*ast.File{file}, nil)
+	return versionErr
+}
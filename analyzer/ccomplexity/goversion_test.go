@@ -0,0 +1,49 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzerGoVersion(t *testing.T) {
+	const srcPath = "./testcode/packages/goversion/_rangefunc.go"
+
+	//Range-over-func requires go1.23.
+	for _, goVersion := range []string{"go1.22", "1.22", "go1.21.5"} {
+		if _, err := NewAnalyzer(WithGoVersion(goVersion)).AnalyzeFile(srcPath); err == nil {
+			t.Errorf("Analysis of %s with Go version %s should fail, but succeeded!\n", srcPath, goVersion)
+		} else if !strings.Contains(err.Error(), "requires go1.23") {
+			t.Errorf("Analysis of %s with Go version %s failed with unexpected error: %s\n", srcPath, goVersion, err)
+		}
+	}
+
+	for _, goVersion := range []string{"", "go1.23", "1.24"} {
+		if _, err := NewAnalyzer(WithGoVersion(goVersion)).AnalyzeFile(srcPath); err != nil {
+			t.Errorf("Analysis of %s with Go version %q should succeed, but failed: %s\n", srcPath, goVersion, err)
+		}
+	}
+
+	if _, err := NewAnalyzer(WithGoVersion("latest")).AnalyzeFile(srcPath); err == nil {
+		t.Error("Analysis with invalid Go version should fail, but succeeded!")
+	}
+}
+
+func TestAnalyzerGoVersionDir(t *testing.T) {
+	analyzer := NewAnalyzer(WithGoVersion("go1.22"))
+	functions, err := analyzer.AnalyzeDir("./testcode/packages/goversion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "sum", Complexity: 2},
+	}
+	if err := verifyCyclomaticComplexity(functions, correctFunctions); err != nil {
+		t.Error(err)
+	}
+	if _, skipped := analyzer.SkippedFiles()["testcode/packages/goversion/_rangefunc.go"]; !skipped {
+		t.Errorf("File _rangefunc.go should be skipped, but skipped files are %v!\n", analyzer.SkippedFiles())
+	}
+}
@@ -106,6 +106,31 @@ func (graph *Graph) InsertEdge(leftNode *Node, rightNode *Node) {
 	}
 }
 
+// RemoveNode removes 'node' from the graph, together
+// with all its ingoing and outgoing edges.
+func (graph *Graph) RemoveNode(node *Node) {
+	for _, inNode := range node.inEdges {
+		inNode.outEdges = removeEdge(inNode.outEdges, node)
+	}
+	for _, outNode := range node.outEdges {
+		outNode.inEdges = removeEdge(outNode.inEdges, node)
+	}
+	node.inEdges, node.outEdges = nil, nil
+	delete(graph.Nodes, node.Value.UID())
+	graph.scc = nil //Components must be found again.
+}
+
+// removeEdge returns 'edges' without the edges to 'node'.
+func removeEdge(edges []*Node, node *Node) []*Node {
+	remaining := edges[:0]
+	for _, edge := range edges {
+		if edge != node {
+			remaining = append(remaining, edge)
+		}
+	}
+	return remaining
+}
+
 // getDFS is an internal helper method for GetDFS() to perform depth-first-search on the graph.
 func (node *Node) getDFS() (nodes []*Node) {
 	if !node.visited {
@@ -190,6 +190,42 @@ func TestDepthFirstSearchInGraph(t *testing.T) {
 
 }
 
+func TestRemoveNodeFromGraph(t *testing.T) {
+	//Create some nodes.
+	a := graph.Node{Value: Letter{"A"}}
+	b := graph.Node{Value: Letter{"B"}}
+	c := graph.Node{Value: Letter{"C"}}
+	d := graph.Node{Value: Letter{"D"}}
+
+	graph := graph.NewGraph()
+
+	//Add directed node-pairs to graph.
+	graph.InsertEdge(&a, &b)
+	graph.InsertEdge(&b, &c)
+	graph.InsertEdge(&c, &a)
+	graph.InsertEdge(&a, &d)
+
+	if graph.GetNumberOfSCComponents() != 2 {
+		t.Errorf("Number of SCC should be 2, but are %d!\n", graph.GetNumberOfSCComponents())
+	}
+
+	graph.RemoveNode(&b)
+
+	if graph.GetNumberOfNodes() != 3 {
+		t.Errorf("Number of nodes should be 3, but are %d!\n", graph.GetNumberOfNodes())
+	}
+	if graph.GetNumberOfEdges() != 2 {
+		t.Errorf("Number of edges should be 2, but are %d!\n", graph.GetNumberOfEdges())
+	}
+	if a.GetOutDegree() != 1 || c.GetInDegree() != 0 {
+		t.Errorf("Edges to and from B should be removed, A has %d out-edges and C %d in-edges!\n",
+			a.GetOutDegree(), c.GetInDegree())
+	}
+	if graph.GetNumberOfSCComponents() != 3 {
+		t.Errorf("Number of SCC should be 3, but are %d!\n", graph.GetNumberOfSCComponents())
+	}
+}
+
 func TestDepthFirstSearchInCycleGraph(t *testing.T) {
 	//Create some nodes.
 	a := graph.Node{Value: Letter{"A"}}
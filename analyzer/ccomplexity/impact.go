@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// analyzeFS computes cyclomatic complexity for each function in the Go source files
+// in fsys, keyed by file path. Like the go tool, test files and files and directories
+// starting with . or _, and testdata directories, are skipped. Generated files are
+// skipped as by AnalyzeDir.
+func analyzeFS(fsys fs.FS) (map[string][]FunctionComplexity, error) {
+	results := map[string][]FunctionComplexity{}
+	err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if filePath != "." && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if path.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") || strings.HasPrefix(name, ".") ||
+			strings.HasPrefix(name, "_") {
+			return nil
+		}
+
+		srcFile, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+		if !(&Options{}).includesSource(srcFile) {
+			return nil
+		}
+		functions, err := analyzeSource(filePath, srcFile)
+		if err != nil {
+			return err
+		}
+		results[filePath] = make([]FunctionComplexity, len(functions))
+		for index, function := range functions {
+			results[filePath][index] = *function
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AnalyzeFSFile computes cyclomatic complexity for each function in the Go source file
+// at filePath in fsys, such as a file embedded with go:embed.
+func AnalyzeFSFile(fsys fs.FS, filePath string) ([]FunctionComplexity, error) {
+	srcFile, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+	functions, err := analyzeSource(filePath, srcFile)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]FunctionComplexity, len(functions))
+	for index, function := range functions {
+		results[index] = *function
+	}
+	return results, nil
+}
+
+// CommitComplexityImpact compares two snapshots of a source tree, such as the trees
+// before and after a commit, and returns the net change in total cyclomatic complexity
+// per package. Packages are keyed by directory, and added or removed packages count
+// from zero.
+func CommitComplexityImpact(oldTree, newTree fs.FS) (map[string]int, error) {
+	oldResults, err := analyzeFS(oldTree)
+	if err != nil {
+		return nil, err
+	}
+	newResults, err := analyzeFS(newTree)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := map[string]int{}
+	for packagePath, stats := range GroupByPackage(newResults) {
+		impact[packagePath] += stats.TotalComplexity
+	}
+	for packagePath, stats := range GroupByPackage(oldResults) {
+		impact[packagePath] -= stats.TotalComplexity
+	}
+	return impact, nil
+}
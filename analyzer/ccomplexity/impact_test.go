@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// snapshotFS returns an in-memory source tree, with the content of each
+// file read from the source file it is mapped to.
+func snapshotFS(t *testing.T, files map[string]string) fstest.MapFS {
+	snapshot := fstest.MapFS{}
+	for filePath, srcPath := range files {
+		srcFile, err := ioutil.ReadFile(srcPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		snapshot[filePath] = &fstest.MapFile{Data: srcFile}
+	}
+	return snapshot
+}
+
+func TestCommitComplexityImpact(t *testing.T) {
+	oldTree := snapshotFS(t, map[string]string{
+		"alpha/alpha.go": "./testcode/packages/alpha/_alpha.go",
+		"beta/beta.go":   "./testcode/packages/beta/_beta.go",
+		"README.md":      "./testcode/_helloworld.go",
+	})
+	newTree := snapshotFS(t, map[string]string{
+		"alpha/alpha.go":      "./testcode/packages/alpha/_alpha.go",
+		"alpha/gcd.go":        "./testcode/_gcd.go",
+		"alpha/gcd_test.go":   "./testcode/_switcher.go",
+		"gamma/gamma.go":      "./testcode/_helloworld.go",
+		"gamma/testdata/x.go": "./testcode/_switcher.go",
+		"gamma/color.go":      "./testcode/packages/generated/_stringer.go", //Generated, skipped.
+	})
+
+	impact, err := CommitComplexityImpact(oldTree, newTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctImpact := map[string]int{
+		"alpha": 3,
+		"beta":  -4,
+		"gamma": 1,
+	}
+	if !reflect.DeepEqual(impact, correctImpact) {
+		t.Errorf("Complexity impact should be %v, and not %v!\n", correctImpact, impact)
+	}
+}
+
+func TestCommitComplexityImpactWithoutBodies(t *testing.T) {
+	//Functions without body and files without functions add no complexity.
+	oldTree := snapshotFS(t, map[string]string{
+		"alpha/alpha.go": "./testcode/packages/alpha/_alpha.go",
+	})
+	newTree := snapshotFS(t, map[string]string{
+		"alpha/alpha.go": "./testcode/packages/alpha/_alpha.go",
+		"clock/clock.go": "./testcode/packages/linkname/_clock.go",
+		"clock/doc.go":   "./testcode/packages/linkname/_doc.go",
+		"docs/doc.go":    "./testcode/packages/doconly/_doc.go",
+	})
+
+	impact, err := CommitComplexityImpact(oldTree, newTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctImpact := map[string]int{
+		"alpha": 0,
+		"clock": 5,
+		"docs":  0,
+	}
+	if !reflect.DeepEqual(impact, correctImpact) {
+		t.Errorf("Complexity impact should be %v, and not %v!\n", correctImpact, impact)
+	}
+}
+
+func TestAnalyzeFSFile(t *testing.T) {
+	tree := snapshotFS(t, map[string]string{
+		"alpha/gcd.go":    "./testcode/_gcd.go",
+		"alpha/broken.go": "./testcode/packages/broken/_broken.go",
+	})
+
+	functions, err := AnalyzeFSFile(tree, "alpha/gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "gcd", Complexity: 2},
+		FunctionComplexity{Name: "main", Complexity: 1},
+	}
+	if len(functions) != len(correctFunctions) {
+		t.Fatalf("Number of functions should be %d, but are %d!\n", len(correctFunctions), len(functions))
+	}
+	for index, function := range functions {
+		if function.Name != correctFunctions[index].Name || function.Complexity != correctFunctions[index].Complexity {
+			t.Errorf("Function should be %s with complexity %d, and not %s with complexity %d!\n",
+				correctFunctions[index].Name, correctFunctions[index].Complexity, function.Name, function.Complexity)
+		}
+	}
+	for _, function := range functions {
+		if function.File != "alpha/gcd.go" {
+			t.Errorf("Function %s should be in file alpha/gcd.go, and not %s!\n", function.Name, function.File)
+		}
+	}
+
+	if _, err := AnalyzeFSFile(tree, "alpha/broken.go"); err == nil {
+		t.Error("Analysing a file failing to parse should fail!")
+	}
+	if _, err := AnalyzeFSFile(tree, "alpha/missing.go"); err == nil {
+		t.Error("Analysing a missing file should fail!")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/ast"
+)
+
+// LoopKind is the kind of loop statement.
+type LoopKind int
+
+//Loop kinds.
+const (
+	FOR_LOOP      LoopKind = iota //For loop with condition.
+	RANGE_LOOP                    //For loop with range clause.
+	INFINITE_LOOP                 //For loop without condition.
+)
+
+var loopKindStrings = [...]string{
+	FOR_LOOP:      "FOR_LOOP",
+	RANGE_LOOP:    "RANGE_LOOP",
+	INFINITE_LOOP: "INFINITE_LOOP",
+}
+
+func (kind LoopKind) String() string {
+	return loopKindStrings[kind]
+}
+
+// LoopInfo describes a loop statement in a function.
+type LoopInfo struct {
+	Function  string   //Name of function containing the loop, receiver-qualified for methods.
+	Kind      LoopKind //Kind of loop.
+	Line      int      //Line number of the loop header.
+	BodyStart int      //Line number of the opening brace of the loop body.
+	BodyEnd   int      //Line number of the closing brace of the loop body.
+	Depth     int      //Number of loops enclosing the loop, 0 for outermost loops.
+}
+
+// Loops returns every loop in the functions in srcFile in source order, with
+// nesting depth counting enclosing loops in the same function.
+func Loops(srcFile []byte) ([]LoopInfo, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	loops := []LoopInfo{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		var collectLoops func(node ast.Node, depth int)
+		collectLoops = func(node ast.Node, depth int) {
+			ast.Inspect(node, func(node ast.Node) bool {
+				var kind LoopKind
+				var body *ast.BlockStmt
+				switch t := node.(type) {
+				case *ast.ForStmt:
+					kind, body = FOR_LOOP, t.Body
+					if t.Cond == nil {
+						kind = INFINITE_LOOP
+					}
+				case *ast.RangeStmt:
+					kind, body = RANGE_LOOP, t.Body
+				default:
+					return true
+				}
+
+				loops = append(loops, LoopInfo{
+					Function:  bblock.QualifiedFuncName(funcDecl),
+					Kind:      kind,
+					Line:      fileSet.Position(node.Pos()).Line,
+					BodyStart: fileSet.Position(body.Lbrace).Line,
+					BodyEnd:   fileSet.Position(body.Rbrace).Line,
+					Depth:     depth,
+				})
+				collectLoops(body, depth+1)
+				return false
+			})
+		}
+		collectLoops(funcDecl.Body, 0)
+	}
+	return loops, nil
+}
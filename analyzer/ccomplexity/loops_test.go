@@ -0,0 +1,32 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestLoops(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_loops.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loops, err := Loops(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctLoops := []LoopInfo{
+		{Function: "sum", Kind: FOR_LOOP, Line: 15, BodyStart: 15, BodyEnd: 19, Depth: 0},
+		{Function: "sum", Kind: RANGE_LOOP, Line: 16, BodyStart: 16, BodyEnd: 18, Depth: 1},
+		{Function: "countdown", Kind: INFINITE_LOOP, Line: 24, BodyStart: 24, BodyEnd: 32, Depth: 0},
+		{Function: "countdown", Kind: FOR_LOOP, Line: 25, BodyStart: 25, BodyEnd: 27, Depth: 1},
+	}
+	if !reflect.DeepEqual(loops, correctLoops) {
+		t.Errorf("Loops should be:\n%+v\nand not:\n%+v\n", correctLoops, loops)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import "github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+
+// NPathComplexity returns the NPath complexity of Nejmeh of each function in blocks, keyed by
+// function name, counting the acyclic execution paths from the function entry to its exits. A
+// function holds the blocks from its FUNCTION_ENTRY block to the next. Sequences multiply the paths
+// of their parts, and an if statement adds the paths of its two branches, the false branch of an
+// if without else being the one path skipping it. A loop has the paths of its body plus one, as
+// each path through the body leaves the loop instead of entering the header again. A switch or
+// select statement adds the paths of its clauses, a clause ending in fallthrough having the
+// paths of the next clause entered after it, and one path skipping the statement. The
+// basic-blocks keep the skipping path also for a switch with default clause, counting the
+// default clause once more than Nejmeh does, as in the cyclomatic complexity.
+func NPathComplexity(blocks []*bblock.BasicBlock) map[string]int {
+	npaths := map[string]int{}
+	for start := 0; start < len(blocks); {
+		end := start + 1
+		for end < len(blocks) && blocks[end].Type != bblock.FUNCTION_ENTRY {
+			end++
+		}
+		if blocks[start].Type == bblock.FUNCTION_ENTRY {
+			npaths[blocks[start].FunctionName] = functionNPath(blocks[start:end])
+		}
+		start = end
+	}
+	return npaths
+}
+
+// functionNPath returns the number of acyclic paths from the first of blocks, the entry of a
+// single function, to the blocks without successors. Back edges to loop headers, found by
+// bblock.NaturalLoops, are followed to the exits of the loop instead.
+func functionNPath(blocks []*bblock.BasicBlock) int {
+	loopBodies := map[*bblock.BasicBlock]map[*bblock.BasicBlock]bool{}
+	for _, loop := range bblock.NaturalLoops(blocks) {
+		loopBodies[loop.Header] = map[*bblock.BasicBlock]bool{}
+		for _, block := range loop.Body {
+			loopBodies[loop.Header][block] = true
+		}
+	}
+
+	//loopExits returns the successors of the loop header outside the loop, leaving enclosing loops
+	//whose header is entered again.
+	var loopExits func(header *bblock.BasicBlock) []*bblock.BasicBlock
+	loopExits = func(header *bblock.BasicBlock) (exits []*bblock.BasicBlock) {
+		for _, successor := range header.GetSuccessorBlocks() {
+			if loopBodies[header][successor] {
+				continue
+			}
+			if loopBodies[successor][header] {
+				exits = append(exits, loopExits(successor)...)
+			} else {
+				exits = append(exits, successor)
+			}
+		}
+		return exits
+	}
+
+	npaths := map[*bblock.BasicBlock]int{}
+	var countPaths func(block *bblock.BasicBlock) int
+	countPaths = func(block *bblock.BasicBlock) int {
+		if npath, ok := npaths[block]; ok {
+			return npath
+		}
+		npaths[block] = 0 //Cycles left, as of goto statements, add no paths.
+
+		var successors []*bblock.BasicBlock
+		for _, successor := range block.GetSuccessorBlocks() {
+			if loopBodies[successor][block] {
+				successors = append(successors, loopExits(successor)...) //Back edge.
+			} else {
+				successors = append(successors, successor)
+			}
+		}
+
+		npath := 0
+		for _, successor := range successors {
+			npath += countPaths(successor)
+		}
+		if len(successors) == 0 {
+			npath = 1 //Exit.
+		}
+		npaths[block] = npath
+		return npath
+	}
+	return countPaths(blocks[0])
+}
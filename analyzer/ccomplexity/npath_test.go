@@ -0,0 +1,43 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+)
+
+func TestNPathComplexity(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_npath.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctNPaths := map[string]int{
+		"sequential":           8, //2 * 2 * 2
+		"branches":             4, //2 * 2
+		"loop":                 3, //Body 2 + 1
+		"nestedLoops":          3, //Inner loop (1 + 1) + 1
+		"spin":                 1, //Loop without exit, body 1.
+		"switchWithoutDefault": 4, //3 cases + 1
+		"switchWithDefault":    3, //2 cases + default
+		"switchFallthrough":    8, //2 * (3 cases + 1)
+		"main":                 1,
+	}
+	npaths := NPathComplexity(blocks)
+	if len(npaths) != len(correctNPaths) {
+		t.Errorf("Number of functions should be %d, but are %d!\n", len(correctNPaths), len(npaths))
+	}
+	for name, correctNPath := range correctNPaths {
+		if npath := npaths[name]; npath != correctNPath {
+			t.Errorf("NPath complexity of %s should be %d, but is %d!\n", name, correctNPath, npath)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Options holds the settings for the function level cyclomatic complexity analysis.
+type Options struct {
+	IncludePrefix    string //Only functions with name starting with prefix are reported, all if empty.
+	Limit            int    //Only the Limit most complex functions are reported, most complex first. All if zero.
+	StrictParse      bool   //Abort directory analysis if any file fails to parse, instead of skipping the file.
+	IncludeGenerated bool   //Include generated files in directory analysis, see IsGeneratedCode.
+	GoVersion        string //Reject files using language features newer than this Go version, unchecked if empty.
+	DiscountErrors   bool   //Leave standard if err != nil checks out of the complexity.
+	Concurrent       bool   //Analyze the functions in each file concurrently, parsing the file once.
+}
+
+// ParseErrors holds the errors of Go source files failing to be read or parsed, keyed by path.
+type ParseErrors map[string]error
+
+func (parseErrors ParseErrors) Error() string {
+	var paths []string
+	for path := range parseErrors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var failures []string
+	for _, path := range paths {
+		failures = append(failures, fmt.Sprintf("%s: %s", path, parseErrors[path]))
+	}
+	return fmt.Sprintf("%d file(s) failed to parse: %s", len(paths), strings.Join(failures, "; "))
+}
+
+// includes reports whether the function is selected for analysis by the options.
+func (options *Options) includes(function *FunctionComplexity) bool {
+	return strings.HasPrefix(function.Name, options.IncludePrefix)
+}
+
+// includesSource reports whether the source file is selected for directory analysis by the options.
+func (options *Options) includesSource(srcFile []byte) bool {
+	return options.IncludeGenerated || !IsGeneratedCode(srcFile)
+}
+
+// GetCyclomaticComplexityFunctionLevelWithOptions computes cyclomatic complexity for
+// each function in srcFile as GetCyclomaticComplexityFunctionLevel, reporting only
+// the functions selected by options.
+func GetCyclomaticComplexityFunctionLevelWithOptions(srcFile []byte, options *Options) ([]*FunctionComplexity, error) {
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	return options.selectFunctions(functions), nil
+}
+
+// FilteredComplexity computes cyclomatic complexity for each function in srcFile as
+// GetCyclomaticComplexityFunctionLevel, reporting only the functions whose name match
+// accepts, in source order. Function literals are matched by their name, as main$func1.
+func FilteredComplexity(srcFile []byte, match func(name string) bool) ([]FunctionComplexity, error) {
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	var selected []FunctionComplexity
+	for _, function := range functions {
+		if match(function.Name) {
+			selected = append(selected, *function)
+		}
+	}
+	return selected, nil
+}
+
+// selectFunctions returns the functions selected by options, most complex first if limited.
+func (options *Options) selectFunctions(functions []*FunctionComplexity) []*FunctionComplexity {
+	var selected []*FunctionComplexity
+	for _, function := range functions {
+		if options.includes(function) {
+			selected = append(selected, function)
+		}
+	}
+
+	if options.Limit > 0 && len(selected) > 0 {
+		sort.SliceStable(selected, func(i, j int) bool {
+			return selected[i].Complexity > selected[j].Complexity
+		})
+		if len(selected) > options.Limit {
+			selected = selected[:options.Limit]
+		}
+	}
+	return selected
+}
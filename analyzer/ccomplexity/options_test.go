@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestIncludePrefixOption(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switcher.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	functions, err := GetCyclomaticComplexityFunctionLevelWithOptions(srcFile, &Options{IncludePrefix: "month"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "monthNumberToString", Complexity: 13},
+	}
+	if err := verifyCyclomaticComplexity(functions, correctFunctions); err != nil {
+		t.Error(err)
+	}
+
+	//Empty prefix includes all functions.
+	functions, err = GetCyclomaticComplexityFunctionLevelWithOptions(srcFile, &Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(functions) != 2 {
+		t.Errorf("Number of functions should be 2, but are %d!\n", len(functions))
+	}
+}
+
+func TestFilteredComplexity(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_ranking.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		description string
+		match       func(name string) bool
+		names       []string
+	}{
+		{"suffix", func(name string) bool { return strings.HasSuffix(name, "n") }, []string{"main", "sign", "countdown"}},
+		{"length", func(name string) bool { return len(name) > 5 }, []string{"countdown", "classify"}},
+		{"none", func(name string) bool { return false }, nil},
+	}
+
+	for _, testCase := range testCases {
+		functions, err := FilteredComplexity(srcFile, testCase.match)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, function := range functions {
+			names = append(names, function.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(testCase.names, ",") {
+			t.Errorf("Functions matching %s should be %v, and not %v!\n", testCase.description, testCase.names, names)
+		}
+	}
+
+	//Complexities are as without filter.
+	functions, err := FilteredComplexity(srcFile, func(name string) bool { return name == "classify" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(functions) != 1 || functions[0].Complexity != 4 || functions[0].Line != 33 {
+		t.Errorf("Function classify should have complexity 4 at line 33, and not be %v!\n", functions)
+	}
+}
+
+func TestLimitOption(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_ranking.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	functions, err := GetCyclomaticComplexityFunctionLevelWithOptions(srcFile, &Options{Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []FunctionComplexity{
+		FunctionComplexity{Name: "classify", Complexity: 4},
+		FunctionComplexity{Name: "countdown", Complexity: 3},
+		FunctionComplexity{Name: "sign", Complexity: 2},
+	}
+	if err := verifyCyclomaticComplexity(functions, correctFunctions); err != nil {
+		t.Error(err)
+	}
+
+	//Limit above number of functions reports all.
+	functions, err = GetCyclomaticComplexityFunctionLevelWithOptions(srcFile, &Options{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(functions) != 5 {
+		t.Errorf("Number of functions should be 5, but are %d!\n", len(functions))
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// PackageStats represents the cyclomatic complexity rolled up for all functions in a package.
+type PackageStats struct {
+	Functions         int     //Number of functions in package.
+	TotalComplexity   int     //Sum of cyclomatic complexity.
+	AverageComplexity float64 //Average cyclomatic complexity per function.
+	MaxComplexity     int     //Highest cyclomatic complexity found in package.
+}
+
+// GroupByPackage rolls up the function level results, keyed by source file path,
+// into statistics per package. A package is identified by the directory of the
+// source file.
+func GroupByPackage(results map[string][]FunctionComplexity) map[string]PackageStats {
+	packages := map[string]PackageStats{}
+
+	for filePath, functions := range results {
+		packagePath := filepath.Dir(filePath)
+		stats := packages[packagePath]
+
+		for _, function := range functions {
+			stats.Functions++
+			stats.TotalComplexity += function.Complexity
+			if function.Complexity > stats.MaxComplexity {
+				stats.MaxComplexity = function.Complexity
+			}
+		}
+		packages[packagePath] = stats
+	}
+
+	for packagePath, stats := range packages {
+		if stats.Functions > 0 {
+			stats.AverageComplexity = float64(stats.TotalComplexity) / float64(stats.Functions)
+		}
+		packages[packagePath] = stats
+	}
+	return packages
+}
+
+// TotalBlocks returns the number of basic-blocks in all functions of the package in
+// dir, a size indicator independent of cyclomatic complexity.
+func TotalBlocks(dir string) (int, error) {
+	goFiles, err := getGoFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, goFile := range goFiles {
+		srcFile, err := ioutil.ReadFile(goFile)
+		if err != nil {
+			return 0, err
+		}
+		basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+		if err != nil {
+			return 0, err
+		}
+		total += len(basicBlocks)
+	}
+	return total, nil
+}
+
+// ComplexityPerKLOC returns the total cyclomatic complexity of the functions in the package
+// in dir per thousand lines of code, a size normalized complexity comparable across code
+// bases. Each function adds one to the total besides its decision points, so splitting code
+// into more functions raises the value. Lines holding only comments or white space are not
+// counted. A package without code has no complexity.
+func ComplexityPerKLOC(dir string) (float64, error) {
+	goFiles, err := getGoFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	complexity, lines := 0, 0
+	for _, goFile := range goFiles {
+		srcFile, err := ioutil.ReadFile(goFile)
+		if err != nil {
+			return 0, err
+		}
+		functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+		if err != nil {
+			return 0, err
+		}
+		for _, function := range functions {
+			complexity += function.Complexity
+		}
+		lines += linesOfCode(srcFile)
+	}
+	if lines == 0 {
+		return 0, nil
+	}
+	return float64(complexity) * 1000 / float64(lines), nil
+}
+
+// linesOfCode returns the number of lines in srcFile holding a token, every line of a
+// multi-line raw string literal included.
+func linesOfCode(srcFile []byte) int {
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(srcFile))
+	var tokenScanner scanner.Scanner
+	tokenScanner.Init(file, srcFile, nil, 0) //Comments are skipped.
+
+	lines := map[int]bool{}
+	for {
+		position, tok, literal := tokenScanner.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && literal == "\n" {
+			continue //Automatically inserted at the end of the line.
+		}
+		line := file.Line(position)
+		for offset := 0; offset <= strings.Count(literal, "\n"); offset++ {
+			lines[line+offset] = true
+		}
+	}
+	return len(lines)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+func TestGroupByPackage(t *testing.T) {
+	srcFiles := []string{
+		"./testcode/packages/alpha/_alpha.go",
+		"./testcode/packages/beta/_beta.go",
+	}
+
+	results := map[string][]FunctionComplexity{}
+	for _, srcPath := range srcFiles {
+		srcFile, err := ioutil.ReadFile(srcPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, function := range functions {
+			results[srcPath] = append(results[srcPath], *function)
+		}
+	}
+
+	correctStats := map[string]PackageStats{
+		filepath.Dir(srcFiles[0]): PackageStats{Functions: 2, TotalComplexity: 3, AverageComplexity: 1.5, MaxComplexity: 2},
+		filepath.Dir(srcFiles[1]): PackageStats{Functions: 1, TotalComplexity: 4, AverageComplexity: 4, MaxComplexity: 4},
+	}
+
+	expectedStats := GroupByPackage(results)
+	if len(expectedStats) != len(correctStats) {
+		t.Fatalf("Number of packages should be %d, but are %d!\n", len(correctStats), len(expectedStats))
+	}
+	for packagePath, correct := range correctStats {
+		if expectedStats[packagePath] != correct {
+			t.Errorf("Package %s should have stats %+v, but has %+v!\n", packagePath, correct, expectedStats[packagePath])
+		}
+	}
+}
+
+func TestTotalBlocks(t *testing.T) {
+	testCases := []struct {
+		dir    string
+		blocks int
+	}{
+		{"./testcode/packages/alpha", 6},
+		{"./testcode/platform", 11},
+		{"./testcode/packages/linkname", 10}, //Functions without body, and a file without functions.
+	}
+
+	for _, testCase := range testCases {
+		blocks, err := TotalBlocks(testCase.dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if blocks != testCase.blocks {
+			t.Errorf("Number of basic-blocks in %s should be %d, but are %d!\n", testCase.dir, testCase.blocks, blocks)
+		}
+	}
+}
+
+func TestComplexityPerKLOC(t *testing.T) {
+	//Complexity 3 in 13 lines of code in _parity.go, 1 decision and 1 for each of its 2 functions, and 3 in
+	//10 lines in _sign.go, 2 decisions and 1 for the function.
+	complexity, err := ComplexityPerKLOC("./testcode/packages/kloc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if correctComplexity := 6 * 1000 / 23.0; math.Abs(complexity-correctComplexity) > 1e-9 {
+		t.Errorf("Complexity per KLOC should be %f, but is %f!\n", correctComplexity, complexity)
+	}
+
+	//Complexity 5 in 23 lines of code in _clock.go, the functions without body adding none, and 1 line
+	//in _doc.go without functions.
+	complexity, err = ComplexityPerKLOC("./testcode/packages/linkname")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if correctComplexity := 5 * 1000 / 24.0; math.Abs(complexity-correctComplexity) > 1e-9 {
+		t.Errorf("Complexity per KLOC should be %f, but is %f!\n", correctComplexity, complexity)
+	}
+
+	if _, err := ComplexityPerKLOC("./testcode/packages/missing"); err == nil {
+		t.Error("Complexity per KLOC of a missing directory should fail!")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"bytes"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// PlatformReport holds the cyclomatic complexity of a package built for different platforms.
+type PlatformReport struct {
+	Platforms map[string][]*FunctionComplexity //Functions in files built for each GOOS.
+	Merged    []*FunctionComplexity            //Functions in files built for any of the GOOS.
+}
+
+// matchPlatform reports whether the Go source file at srcPath is built for goos,
+// according to its file name suffix and build constraints. Files prefixed by '_',
+// as in the testcode directories, are matched as if the prefix was not there.
+func matchPlatform(srcPath string, srcFile []byte, goos string) (bool, error) {
+	context := build.Default
+	context.GOOS = goos
+	context.OpenFile = func(path string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(srcFile)), nil
+	}
+	dir, name := filepath.Split(srcPath)
+	return context.MatchFile(dir, strings.TrimPrefix(name, "_"))
+}
+
+// AnalyzePlatformVariants computes cyclomatic complexity of the package in dir for
+// each GOOS in platforms, taking file name suffixes such as _linux.go and build
+// constraints into account. The merged view holds every file built for any of the
+// platforms, each analysed once.
+func AnalyzePlatformVariants(dir string, platforms ...string) (*PlatformReport, error) {
+	goFiles, err := getGoFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PlatformReport{Platforms: map[string][]*FunctionComplexity{}}
+	for _, goFile := range goFiles {
+		srcFile, err := ioutil.ReadFile(goFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var matchedPlatforms []string
+		for _, goos := range platforms {
+			match, err := matchPlatform(goFile, srcFile, goos)
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				matchedPlatforms = append(matchedPlatforms, goos)
+			}
+		}
+		if len(matchedPlatforms) == 0 {
+			continue
+		}
+
+		functions, err := analyzeFile(goFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, goos := range matchedPlatforms {
+			report.Platforms[goos] = append(report.Platforms[goos], functions...)
+		}
+		report.Merged = append(report.Merged, functions...)
+	}
+	return report, nil
+}
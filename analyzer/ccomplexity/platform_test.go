@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzePlatformVariants(t *testing.T) {
+	report, err := AnalyzePlatformVariants("./testcode/platform", "linux", "windows")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name      string
+		functions []*FunctionComplexity
+		correct   []FunctionComplexity
+	}{
+		{"linux", report.Platforms["linux"], []FunctionComplexity{
+			FunctionComplexity{Name: "newReader", Complexity: 1},
+			FunctionComplexity{Name: "(*reader).separator", Complexity: 1},
+		}},
+		{"windows", report.Platforms["windows"], []FunctionComplexity{
+			FunctionComplexity{Name: "newReader", Complexity: 1},
+			FunctionComplexity{Name: "(*reader).separator", Complexity: 2},
+		}},
+		{"merged", report.Merged, []FunctionComplexity{
+			FunctionComplexity{Name: "newReader", Complexity: 1},
+			FunctionComplexity{Name: "(*reader).separator", Complexity: 1},
+			FunctionComplexity{Name: "(*reader).separator", Complexity: 2},
+		}},
+	}
+
+	for _, testCase := range testCases {
+		if err := verifyCyclomaticComplexity(testCase.functions, testCase.correct); err != nil {
+			t.Errorf("%s: %s", testCase.name, err)
+		}
+	}
+
+	if file := filepath.Base(report.Platforms["windows"][1].File); file != "_reader_windows.go" {
+		t.Errorf("Windows variant of separator should be found in _reader_windows.go, and not %s!\n", file)
+	}
+}
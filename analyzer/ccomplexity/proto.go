@@ -0,0 +1,175 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ProtoVersion is the version of the complexity.proto schema written by MarshalProto.
+const ProtoVersion = 1
+
+//Protocol Buffers wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+//Field numbers of the ComplexityResults message.
+const (
+	resultsVersionField   = 1
+	resultsFunctionsField = 2
+)
+
+//Field numbers of the FunctionComplexity message.
+const (
+	functionNameField       = 1
+	functionFileField       = 2
+	functionLineField       = 3
+	functionComplexityField = 4
+	functionKindField       = 5
+)
+
+// MarshalProto returns results encoded as a ComplexityResults message of complexity.proto,
+// in the Protocol Buffers wire format. The control-flow graphs and basic-blocks of the
+// functions are not encoded.
+func MarshalProto(results []FunctionComplexity) ([]byte, error) {
+	data := appendVarintField(nil, resultsVersionField, ProtoVersion)
+	for _, function := range results {
+		var message []byte
+		message = appendBytesField(message, functionNameField, []byte(function.Name))
+		message = appendBytesField(message, functionFileField, []byte(function.File))
+		message = appendVarintField(message, functionLineField, uint64(function.Line))
+		message = appendVarintField(message, functionComplexityField, uint64(function.Complexity))
+		message = appendVarintField(message, functionKindField, uint64(function.Kind))
+		data = appendLengthDelimited(data, resultsFunctionsField, message)
+	}
+	return data, nil
+}
+
+// UnmarshalProto returns the functions of the ComplexityResults message encoded in data, as
+// written by MarshalProto. Unknown fields are skipped, messages of a newer schema version
+// than ProtoVersion give an error.
+func UnmarshalProto(data []byte) ([]FunctionComplexity, error) {
+	var results []FunctionComplexity
+	err := eachProtoField(data, func(field int, varint uint64, message []byte) error {
+		switch field {
+		case resultsVersionField:
+			if varint > ProtoVersion {
+				return fmt.Errorf("unsupported schema version %d", varint)
+			}
+		case resultsFunctionsField:
+			function, err := unmarshalFunction(message)
+			if err != nil {
+				return err
+			}
+			results = append(results, function)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// unmarshalFunction returns the function encoded in the FunctionComplexity message.
+func unmarshalFunction(message []byte) (function FunctionComplexity, err error) {
+	err = eachProtoField(message, func(field int, varint uint64, bytes []byte) error {
+		switch field {
+		case functionNameField:
+			function.Name = string(bytes)
+		case functionFileField:
+			function.File = string(bytes)
+		case functionLineField:
+			function.Line = int(int64(varint))
+		case functionComplexityField:
+			function.Complexity = int(int64(varint))
+		case functionKindField:
+			if varint >= uint64(len(testKindStrings)) {
+				return fmt.Errorf("unknown test kind %d", varint)
+			}
+			function.Kind = TestKind(varint)
+		}
+		return nil
+	})
+	return function, err
+}
+
+// eachProtoField calls fn with the number and value of each field in message, the value of
+// varint fields in varint and of length-delimited fields in bytes. Fixed-size fields are
+// skipped. The first error returned by fn is returned.
+func eachProtoField(message []byte, fn func(field int, varint uint64, bytes []byte) error) error {
+	for len(message) > 0 {
+		key, n := binary.Uvarint(message)
+		if n <= 0 {
+			return fmt.Errorf("invalid field key")
+		}
+		message = message[n:]
+		field, wireType := int(key>>3), key&7
+
+		var varint uint64
+		var bytes []byte
+		switch wireType {
+		case wireVarint:
+			varint, n = binary.Uvarint(message)
+			if n <= 0 {
+				return fmt.Errorf("invalid varint in field %d", field)
+			}
+			message = message[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(message)
+			if n <= 0 || uint64(len(message)-n) < length {
+				return fmt.Errorf("invalid length of field %d", field)
+			}
+			bytes = message[n : n+int(length)]
+			message = message[n+int(length):]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if wireType == wireFixed32 {
+				size = 4
+			}
+			if len(message) < size {
+				return fmt.Errorf("truncated field %d", field)
+			}
+			message = message[size:]
+			continue
+		default:
+			return fmt.Errorf("unsupported wire type %d of field %d", wireType, field)
+		}
+		if err := fn(field, varint, bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendVarintField appends the varint field with value to data. Zero values are left out,
+// as in proto3.
+func appendVarintField(data []byte, field int, value uint64) []byte {
+	if value == 0 {
+		return data
+	}
+	data = binary.AppendUvarint(data, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(data, value)
+}
+
+// appendBytesField appends the string or bytes field with value to data. Empty values are
+// left out, as in proto3.
+func appendBytesField(data []byte, field int, value []byte) []byte {
+	if len(value) == 0 {
+		return data
+	}
+	return appendLengthDelimited(data, field, value)
+}
+
+// appendLengthDelimited appends the length-delimited field with value to data.
+func appendLengthDelimited(data []byte, field int, value []byte) []byte {
+	data = binary.AppendUvarint(data, uint64(field)<<3|wireBytes)
+	data = binary.AppendUvarint(data, uint64(len(value)))
+	return append(data, value...)
+}
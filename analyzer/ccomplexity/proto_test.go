@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	analyzed, err := NewAnalyzer().AnalyzeFile("./testcode/_selectkinds.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []FunctionComplexity
+	for _, function := range analyzed {
+		//Control-flow graphs and basic-blocks are not encoded.
+		results = append(results, FunctionComplexity{Name: function.Name, File: function.File, Line: function.Line,
+			Complexity: function.Complexity, Kind: function.Kind})
+	}
+	results = append(results,
+		FunctionComplexity{Name: "TestGcd", File: "gcd_test.go", Line: 12, Complexity: 3, Kind: TEST},
+		FunctionComplexity{Name: "(*Store).Save", Line: -1, Kind: FUZZ}, //Zero and negative values.
+		FunctionComplexity{},
+	)
+
+	data, err := MarshalProto(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled, err := UnmarshalProto(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unmarshaled, results) {
+		t.Errorf("Unmarshaled results should be %v, and not %v!\n", results, unmarshaled)
+	}
+}
+
+func TestMarshalProtoWireFormat(t *testing.T) {
+	data, err := MarshalProto([]FunctionComplexity{{Name: "f", Line: 1, Complexity: 2, Kind: BENCHMARK}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctData := []byte{
+		0x08, 0x01, //Version 1.
+		0x12, 0x09, //Function of 9 bytes.
+		0x0a, 0x01, 'f', //Name.
+		0x18, 0x01, //Line.
+		0x20, 0x02, //Complexity.
+		0x28, 0x02, //Kind.
+	}
+	if !bytes.Equal(data, correctData) {
+		t.Errorf("Encoded results should be % x, and not % x!\n", correctData, data)
+	}
+}
+
+func TestUnmarshalProtoErrors(t *testing.T) {
+	//Unknown varint, fixed and length-delimited fields are skipped.
+	data := []byte{0x08, 0x01, 0x78, 0x07, 0x81, 0x01, 1, 2, 3, 4, 5, 6, 7, 8, 0x12, 0x05, 0x0a, 0x01, 'f', 0x7a, 0x00}
+	results, err := UnmarshalProto(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(results, []FunctionComplexity{{Name: "f"}}) {
+		t.Errorf("Unmarshaled results should be function f, and not %v!\n", results)
+	}
+
+	invalidData := map[string][]byte{
+		"newer version":    {0x08, 0x02},
+		"truncated length": {0x12, 0x05, 0x0a},
+		"truncated varint": {0x08, 0x80},
+		"unknown kind":     {0x12, 0x02, 0x28, 0x09},
+		"group wire type":  {0x0b},
+	}
+	for name, data := range invalidData {
+		if _, err := UnmarshalProto(data); err == nil {
+			t.Errorf("Unmarshaling %s should give an error!\n", name)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/cfgraph"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// prometheusLabelEscaper escapes label values according to the Prometheus text exposition format.
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WritePrometheus writes the cyclomatic complexity of each function to w in the
+// Prometheus text exposition format, one code_cyclomatic_complexity sample per function.
+func WritePrometheus(w io.Writer, results []FunctionComplexity) error {
+	writer := bufio.NewWriter(w)
+
+	fmt.Fprintln(writer, "# HELP code_cyclomatic_complexity Cyclomatic complexity of a function.")
+	fmt.Fprintln(writer, "# TYPE code_cyclomatic_complexity gauge")
+	for _, function := range results {
+		fmt.Fprintf(writer, "code_cyclomatic_complexity{func=\"%s\",file=\"%s\"} %d\n",
+			prometheusLabelEscaper.Replace(function.Name), prometheusLabelEscaper.Replace(function.File),
+			function.Complexity)
+	}
+	return writer.Flush()
+}
+
+// junitTestSuite is the root element in a JUnit XML report.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a single function in a JUnit XML report.
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+// junitFailure marks a function with complexity above threshold in a JUnit XML report.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes a JUnit XML report to w, where each function is a test case
+// failing if its cyclomatic complexity is above threshold.
+func WriteJUnit(w io.Writer, results []FunctionComplexity, threshold int) error {
+	testSuite := junitTestSuite{Name: "cyclomatic-complexity", Tests: len(results)}
+
+	for _, function := range results {
+		testCase := junitTestCase{ClassName: function.File, Name: function.Name}
+		if function.Complexity > threshold {
+			testSuite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("cyclomatic complexity %d exceeds threshold %d", function.Complexity, threshold),
+				Type:    "complexity",
+				Text:    fmt.Sprintf("%s:%d: %s has cyclomatic complexity %d", function.File, function.Line, function.Name, function.Complexity),
+			}
+		}
+		testSuite.TestCases = append(testSuite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(testSuite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ComplexityBand returns the band "low", "medium" or "high" of complexity c, letting
+// renderers color results consistently. Complexity up to and including thresholds[0]
+// is low, up to and including thresholds[1] medium, and above that high.
+func ComplexityBand(c int, thresholds [2]int) string {
+	switch {
+	case c <= thresholds[0]:
+		return "low"
+	case c <= thresholds[1]:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// ComplexityThresholds are the highest complexities given each letter grade, complexity
+// above E is graded F.
+type ComplexityThresholds struct {
+	A int //Highest complexity graded A.
+	B int //Highest complexity graded B.
+	C int //Highest complexity graded C.
+	D int //Highest complexity graded D.
+	E int //Highest complexity graded E.
+}
+
+// DefaultComplexityThresholds grades complexity 1-5 A, 6-10 B, 11-20 C, 21-30 D, 31-40 E
+// and 41 and above F.
+var DefaultComplexityThresholds = ComplexityThresholds{A: 5, B: 10, C: 20, D: 30, E: 40}
+
+// Grade returns the letter grade "A" to "F" of complexity c.
+func (thresholds ComplexityThresholds) Grade(c int) string {
+	switch {
+	case c <= thresholds.A:
+		return "A"
+	case c <= thresholds.B:
+		return "B"
+	case c <= thresholds.C:
+		return "C"
+	case c <= thresholds.D:
+		return "D"
+	case c <= thresholds.E:
+		return "E"
+	default:
+		return "F"
+	}
+}
+
+// GradeReport returns the letter grade of the cyclomatic complexity of each function in
+// srcFile, computed by ComplexityFromSource and keyed by function name.
+func (thresholds ComplexityThresholds) GradeReport(srcFile []byte) (map[string]string, error) {
+	complexities, err := ComplexityFromSource(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	grades := make(map[string]string, len(complexities))
+	for name, complexity := range complexities {
+		grades[name] = thresholds.Grade(complexity)
+	}
+	return grades, nil
+}
+
+// ComplexityGrade returns the letter grade of complexity with DefaultComplexityThresholds.
+func ComplexityGrade(complexity int) string {
+	return DefaultComplexityThresholds.Grade(complexity)
+}
+
+// GradeReport returns the letter grade of each function in srcFile with
+// DefaultComplexityThresholds, keyed by function name.
+func GradeReport(srcFile []byte) (map[string]string, error) {
+	return DefaultComplexityThresholds.GradeReport(srcFile)
+}
+
+// htmlBandThresholds are the complexity thresholds used to color functions in the HTML report.
+var htmlBandThresholds = [2]int{10, 20}
+
+// htmlReport is the page template for WriteHTML, a table sortable by clicking a
+// column header followed by the control-flow graph of each function in DOT format.
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Cyclomatic complexity</title>
+<style>
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+th { cursor: pointer; }
+.low { background: #dfd; }
+.medium { background: #ffd; }
+.high { background: #fdd; }
+</style>
+</head>
+<body>
+<h1>Cyclomatic complexity</h1>
+<table id="functions">
+<thead><tr><th>Function</th><th>File</th><th>Line</th><th>Complexity</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr class="{{.Band}}"><td><a href="#{{.Anchor}}">{{.Name}}</a></td><td>{{.File}}</td><td>{{.Line}}</td><td>{{.Complexity}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+<h2>Control-flow graphs</h2>
+<p>Graphs are in DOT format, render them with Graphviz (www.graphviz.org).</p>
+{{- range .}}
+<section id="{{.Anchor}}">
+<h3>{{.Name}}</h3>
+{{- if .Graph}}
+<pre>{{.Graph}}</pre>
+{{- else}}
+<p>No control-flow graph.</p>
+{{- end}}
+</section>
+{{- end}}
+<script>
+document.querySelectorAll("#functions th").forEach(function(header, column) {
+	header.addEventListener("click", function() {
+		var body = document.querySelector("#functions tbody");
+		var rows = Array.prototype.slice.call(body.rows);
+		var numeric = column >= 2;
+		rows.sort(function(a, b) {
+			var x = a.cells[column].textContent, y = b.cells[column].textContent;
+			return numeric ? y - x : x.localeCompare(y);
+		});
+		rows.forEach(function(row) { body.appendChild(row); });
+	});
+});
+</script>
+</body>
+</html>
+`))
+
+// htmlFunction is a function row and graph section in the HTML report.
+type htmlFunction struct {
+	FunctionComplexity
+	Anchor string //Element id of the graph section.
+	Band   string //Complexity band, used as row class.
+	Graph  string //Control-flow graph in DOT format, empty if unknown.
+}
+
+// dotGraph returns cfg in the DOT format, as written by ControlFlowGraph.WriteDot.
+func dotGraph(name string, cfg *cfgraph.ControlFlowGraph) string {
+	var content bytes.Buffer
+	cfg.WriteDot(&content, name) //Writing to a buffer never fails.
+	return strings.TrimSuffix(content.String(), "\n")
+}
+
+// WriteHTML writes a self-contained HTML report to w, with a sortable table of the
+// functions in results and a section per function holding its control-flow graph in
+// DOT format. Graphs are looked up by function name, functions missing from graphs
+// get a section without graph.
+func WriteHTML(w io.Writer, results []FunctionComplexity, graphs map[string]*cfgraph.ControlFlowGraph) error {
+	var functions []htmlFunction
+	for index, function := range results {
+		htmlFunction := htmlFunction{
+			FunctionComplexity: function,
+			Anchor:             fmt.Sprintf("function-%d", index),
+			Band:               ComplexityBand(function.Complexity, htmlBandThresholds),
+		}
+		if cfg, ok := graphs[function.Name]; ok && cfg != nil {
+			htmlFunction.Graph = dotGraph(function.Name, cfg)
+		}
+		functions = append(functions, htmlFunction)
+	}
+	return htmlReport.Execute(w, functions)
+}
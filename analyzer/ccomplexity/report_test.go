@@ -0,0 +1,197 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/cfgraph"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	results := []FunctionComplexity{
+		FunctionComplexity{Name: "gcd", File: "testcode/_gcd.go", Complexity: 2},
+		FunctionComplexity{Name: "main", File: `C:\src\"quoted"` + "\n.go", Complexity: 1},
+	}
+
+	var output bytes.Buffer
+	if err := WritePrometheus(&output, results); err != nil {
+		t.Fatal(err)
+	}
+
+	correctOutput := `# HELP code_cyclomatic_complexity Cyclomatic complexity of a function.
+# TYPE code_cyclomatic_complexity gauge
+code_cyclomatic_complexity{func="gcd",file="testcode/_gcd.go"} 2
+code_cyclomatic_complexity{func="main",file="C:\\src\\\"quoted\"\n.go"} 1
+`
+	if output.String() != correctOutput {
+		t.Errorf("Prometheus output should be:\n%s\nand not:\n%s\n", correctOutput, output.String())
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	results := []FunctionComplexity{
+		FunctionComplexity{Name: "main", File: "_switcher.go", Line: 8, Complexity: 1},
+		FunctionComplexity{Name: "monthNumberToString", File: "_switcher.go", Line: 13, Complexity: 14},
+		FunctionComplexity{Name: "gcd", File: "_gcd.go", Line: 8, Complexity: 2},
+	}
+
+	var output bytes.Buffer
+	if err := WriteJUnit(&output, results, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	var testSuite junitTestSuite
+	if err := xml.Unmarshal(output.Bytes(), &testSuite); err != nil {
+		t.Fatalf("JUnit report is not well-formed XML: %s\n", err)
+	}
+	if testSuite.Tests != 3 || len(testSuite.TestCases) != 3 {
+		t.Errorf("JUnit report should have 3 test cases, but has %d (%d)!\n", testSuite.Tests, len(testSuite.TestCases))
+	}
+	if testSuite.Failures != 1 {
+		t.Fatalf("JUnit report should have 1 failure, but has %d!\n", testSuite.Failures)
+	}
+
+	failure := testSuite.TestCases[1].Failure
+	if failure == nil || !strings.Contains(failure.Message, "complexity 14") {
+		t.Errorf("Test case monthNumberToString should fail with complexity 14 in message, but failure is %+v!\n", failure)
+	}
+	if testSuite.TestCases[0].Failure != nil || testSuite.TestCases[2].Failure != nil {
+		t.Error("Only test case monthNumberToString should fail!")
+	}
+}
+
+func TestComplexityBand(t *testing.T) {
+	thresholds := [2]int{10, 20}
+	testCases := []struct {
+		complexity int
+		band       string
+	}{
+		{1, "low"},
+		{10, "low"},
+		{11, "medium"},
+		{20, "medium"},
+		{21, "high"},
+		{100, "high"},
+	}
+
+	for _, testCase := range testCases {
+		if band := ComplexityBand(testCase.complexity, thresholds); band != testCase.band {
+			t.Errorf("Band of complexity %d should be %s, but is %s!\n", testCase.complexity, testCase.band, band)
+		}
+	}
+}
+
+func TestComplexityGrade(t *testing.T) {
+	testCases := []struct {
+		complexity int
+		grade      string
+	}{
+		{1, "A"},
+		{5, "A"},
+		{6, "B"},
+		{10, "B"},
+		{11, "C"},
+		{20, "C"},
+		{21, "D"},
+		{30, "D"},
+		{31, "E"},
+		{40, "E"},
+		{41, "F"},
+		{100, "F"},
+	}
+
+	for _, testCase := range testCases {
+		if grade := ComplexityGrade(testCase.complexity); grade != testCase.grade {
+			t.Errorf("Grade of complexity %d should be %s, but is %s!\n", testCase.complexity, testCase.grade, grade)
+		}
+	}
+
+	//Tuned thresholds move the boundaries.
+	thresholds := ComplexityThresholds{A: 2, B: 4, C: 8, D: 16, E: 32}
+	for complexity, grade := range map[int]string{2: "A", 3: "B", 16: "D", 32: "E", 33: "F"} {
+		if tunedGrade := thresholds.Grade(complexity); tunedGrade != grade {
+			t.Errorf("Grade of complexity %d with thresholds %+v should be %s, but is %s!\n", complexity,
+				thresholds, grade, tunedGrade)
+		}
+	}
+}
+
+func TestGradeReport(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switcher.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		thresholds ComplexityThresholds
+		grades     map[string]string
+	}{
+		{DefaultComplexityThresholds, map[string]string{"main": "A", "monthNumberToString": "C"}},
+		{ComplexityThresholds{A: 2, B: 4, C: 8, D: 16, E: 32}, map[string]string{"main": "A", "monthNumberToString": "D"}},
+	}
+
+	for _, testCase := range testCases {
+		grades, err := testCase.thresholds.GradeReport(srcFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(grades, testCase.grades) {
+			t.Errorf("Grades with thresholds %+v should be %v, and not %v!\n", testCase.thresholds, testCase.grades, grades)
+		}
+	}
+
+	grades, err := GradeReport(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(grades, testCases[0].grades) {
+		t.Errorf("Grades should be %v, and not %v!\n", testCases[0].grades, grades)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_gcd.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []FunctionComplexity
+	graphs := map[string]*cfgraph.ControlFlowGraph{}
+	for _, function := range functions {
+		results = append(results, *function)
+		graphs[function.Name] = function.ControlFlowGraph
+	}
+	results = append(results, FunctionComplexity{Name: "<missing>", Complexity: 25})
+
+	var output bytes.Buffer
+	if err := WriteHTML(&output, results, graphs); err != nil {
+		t.Fatal(err)
+	}
+	html := output.String()
+
+	for _, part := range []string{
+		`<tr class="low"><td><a href="#function-0">gcd</a></td><td></td><td>8</td><td>2</td></tr>`,
+		`<tr class="low"><td><a href="#function-1">main</a></td><td></td><td>15</td><td>1</td></tr>`,
+		`<tr class="high"><td><a href="#function-2">&lt;missing&gt;</a></td><td></td><td>0</td><td>25</td></tr>`,
+		`<section id="function-0">`,
+		`<section id="function-1">`,
+		`<section id="function-2">`,
+		`digraph &#34;gcd&#34; {`,
+		`digraph &#34;main&#34; {`,
+		`&#34;BLOCK NR.1 (FOR_STATEMENT) (EndLine: 9)&#34; -&gt; &#34;BLOCK NR.2 (FOR_BODY) (EndLine: 11)&#34;;`,
+		`<p>No control-flow graph.</p>`,
+	} {
+		if !strings.Contains(html, part) {
+			t.Errorf("HTML report should contain %q!\n", part)
+		}
+	}
+}
@@ -0,0 +1,485 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/chrisbbe/GoAnalysis/analyzer/ccomplexity/bblock"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+)
+
+// CaseGroup holds case clauses in a single switch statement having structurally identical bodies.
+type CaseGroup struct {
+	Function   string //Name of function containing the switch, receiver-qualified for methods.
+	SwitchLine int    //Line number of the switch statement.
+	CaseLines  []int  //Line numbers of the case clauses with identical bodies.
+}
+
+// parseSourceCode parses srcFile, returning the file set and file AST. Constructs not terminated
+// are reported as bblock.UnterminatedError.
+func parseSourceCode(srcFile []byte) (*token.FileSet, *ast.File, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", srcFile, 0)
+	if err != nil {
+		return nil, nil, bblock.Unterminated(err)
+	}
+	return fileSet, file, nil
+}
+
+// fingerprint returns a position independent string describing the structure of the nodes,
+// two lists of statements have equal fingerprints if they are equal except for formatting.
+func fingerprint(nodes ...ast.Node) string {
+	var print bytes.Buffer
+	for _, node := range nodes {
+		ast.Inspect(node, func(node ast.Node) bool {
+			if node == nil {
+				print.WriteString(")")
+				return false
+			}
+			fmt.Fprintf(&print, "(%T", node)
+			switch t := node.(type) {
+			case *ast.Ident:
+				fmt.Fprintf(&print, " %s", t.Name)
+			case *ast.BasicLit:
+				fmt.Fprintf(&print, " %s", t.Value)
+			case *ast.BinaryExpr:
+				fmt.Fprintf(&print, " %s", t.Op)
+			case *ast.UnaryExpr:
+				fmt.Fprintf(&print, " %s", t.Op)
+			case *ast.AssignStmt:
+				fmt.Fprintf(&print, " %s", t.Tok)
+			case *ast.IncDecStmt:
+				fmt.Fprintf(&print, " %s", t.Tok)
+			case *ast.BranchStmt:
+				fmt.Fprintf(&print, " %s", t.Tok)
+			}
+			return true
+		})
+	}
+	return print.String()
+}
+
+// DuplicateCases returns groups of case clauses in the same switch statement having
+// structurally identical bodies, likely copy-pasted and candidates for merging into
+// a single multi-value case. Empty case bodies are ignored.
+func DuplicateCases(srcFile []byte) (caseGroups []CaseGroup, err error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			var switchBody *ast.BlockStmt
+			switch t := node.(type) {
+			case *ast.SwitchStmt:
+				switchBody = t.Body
+			case *ast.TypeSwitchStmt:
+				switchBody = t.Body
+			default:
+				return true
+			}
+
+			groups := map[string][]int{}
+			var order []string
+			for _, stmt := range switchBody.List {
+				caseClause := stmt.(*ast.CaseClause)
+				if len(caseClause.Body) == 0 {
+					continue
+				}
+				var body []ast.Node
+				for _, bodyStmt := range caseClause.Body {
+					body = append(body, bodyStmt)
+				}
+				key := fingerprint(body...)
+				if _, ok := groups[key]; !ok {
+					order = append(order, key)
+				}
+				groups[key] = append(groups[key], fileSet.Position(caseClause.Pos()).Line)
+			}
+
+			for _, key := range order {
+				if len(groups[key]) > 1 {
+					caseGroups = append(caseGroups, CaseGroup{
+						Function:   bblock.QualifiedFuncName(funcDecl),
+						SwitchLine: fileSet.Position(node.Pos()).Line,
+						CaseLines:  groups[key],
+					})
+				}
+			}
+			return true
+		})
+	}
+	return caseGroups, nil
+}
+
+// CompoundLines returns the sorted line numbers where more than one control
+// structure (if, for, range, switch and select) starts on the same line.
+func CompoundLines(srcFile []byte) ([]int, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	controlStructures := map[int]int{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			controlStructures[fileSet.Position(node.Pos()).Line]++
+		}
+		return true
+	})
+
+	lines := []int{}
+	for line, count := range controlStructures {
+		if count > 1 {
+			lines = append(lines, line)
+		}
+	}
+	sort.Ints(lines)
+	return lines, nil
+}
+
+// SwitchesMissingDefault returns the line numbers of expression switch statements
+// without a default clause. Type switches are not reported.
+func SwitchesMissingDefault(srcFile []byte) ([]int, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []int{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if switchStmt, ok := node.(*ast.SwitchStmt); ok {
+			hasDefault := false
+			for _, stmt := range switchStmt.Body.List {
+				if caseClause, ok := stmt.(*ast.CaseClause); ok && caseClause.List == nil {
+					hasDefault = true
+				}
+			}
+			if !hasDefault {
+				lines = append(lines, fileSet.Position(switchStmt.Pos()).Line)
+			}
+		}
+		return true
+	})
+	return lines, nil
+}
+
+// LongIfLadders returns the start lines of if/else-if chains with at least minBranches
+// arms, counting every condition and a final else. Long ladders are often better
+// expressed as a switch statement.
+func LongIfLadders(srcFile []byte, minBranches int) ([]int, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []int{}
+	elseIfs := map[*ast.IfStmt]bool{} //If statements continuing a chain, not starting one.
+	ast.Inspect(file, func(node ast.Node) bool {
+		ifStmt, ok := node.(*ast.IfStmt)
+		if !ok || elseIfs[ifStmt] {
+			return true
+		}
+
+		branches := 1
+		for arm := ifStmt; arm.Else != nil; {
+			branches++
+			elseIf, ok := arm.Else.(*ast.IfStmt)
+			if !ok {
+				break //Final else.
+			}
+			elseIfs[elseIf] = true
+			arm = elseIf
+		}
+		if branches >= minBranches {
+			lines = append(lines, fileSet.Position(ifStmt.Pos()).Line)
+		}
+		return true
+	})
+	return lines, nil
+}
+
+// IdenticalBranches returns the line numbers of if statements whose body and final else
+// have the same structure, which usually means a copy-paste bug or a condition without
+// effect. Else-if arms and empty bodies are not compared.
+func IdenticalBranches(srcFile []byte) ([]int, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []int{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		ifStmt, ok := node.(*ast.IfStmt)
+		if !ok {
+			return true
+		}
+		elseBlock, ok := ifStmt.Else.(*ast.BlockStmt)
+		if !ok || len(ifStmt.Body.List) == 0 {
+			return true
+		}
+		if fingerprint(ifStmt.Body) == fingerprint(elseBlock) {
+			lines = append(lines, fileSet.Position(ifStmt.Pos()).Line)
+		}
+		return true
+	})
+	return lines, nil
+}
+
+// UnbalancedBranches returns the number of if statements without an else in each
+// function in srcFile, keyed by receiver-qualified function name. The last if in an else-if chain without
+// a final else is counted. Function literals are left out, as functions of their own.
+func UnbalancedBranches(srcFile []byte) (map[string]int, error) {
+	_, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := map[string]int{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		name := bblock.QualifiedFuncName(funcDecl)
+		branches[name] = 0
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.IfStmt:
+				if t.Else == nil {
+					branches[name]++
+				}
+			}
+			return true
+		})
+	}
+	return branches, nil
+}
+
+// controlStructureDepths returns the nesting depth of each control structure (if, for,
+// range, switch, type switch and select) in body in source order, 1 for the outermost.
+// Else-if arms have the depth of the if statement they continue.
+func controlStructureDepths(body *ast.BlockStmt) (depths []int) {
+	var visit func(node ast.Node, depth int)
+	visit = func(node ast.Node, depth int) {
+		ast.Inspect(node, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.IfStmt:
+				for arm := t; arm != nil; {
+					depths = append(depths, depth+1)
+					visit(arm.Body, depth+1)
+					elseIf, ok := arm.Else.(*ast.IfStmt)
+					if !ok && arm.Else != nil {
+						visit(arm.Else, depth+1)
+					}
+					arm = elseIf
+				}
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				depths = append(depths, depth+1)
+				ast.Inspect(node, func(child ast.Node) bool {
+					if child == node {
+						return true
+					}
+					if blockStmt, ok := child.(*ast.BlockStmt); ok {
+						visit(blockStmt, depth+1)
+					}
+					return false
+				})
+			default:
+				return true
+			}
+			return false
+		})
+	}
+	visit(body, 0)
+	return depths
+}
+
+// ArrowCodeFunctions returns the names of functions in srcFile shaped as arrow code,
+// control structures nested deeper than maxDepth with each nested in the previous one.
+// Visiting the control structures in source order, a run of structures each one level
+// deeper than the previous must be longer than maxDepth.
+func ArrowCodeFunctions(srcFile []byte, maxDepth int) ([]string, error) {
+	_, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := []string{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		run := 0
+		previousDepth := 0
+		for _, depth := range controlStructureDepths(funcDecl.Body) {
+			if depth == previousDepth+1 {
+				run++
+			} else {
+				run = 1
+			}
+			previousDepth = depth
+			if run > maxDepth {
+				functions = append(functions, bblock.QualifiedFuncName(funcDecl))
+				break
+			}
+		}
+	}
+	return functions, nil
+}
+
+// GotoStateMachines returns the names of functions in srcFile implemented as goto based
+// state machines, having at least minStates labels targeted by goto statements. Such
+// functions, often found in generated parsers, can be excluded from complexity budgets.
+func GotoStateMachines(srcFile []byte, minStates int) ([]string, error) {
+	_, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := []string{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		labels := map[string]bool{}
+		gotoTargets := map[string]bool{}
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				return false //Labels are scoped to the function literal.
+			case *ast.LabeledStmt:
+				labels[t.Label.Name] = true
+			case *ast.BranchStmt:
+				if t.Tok == token.GOTO && t.Label != nil {
+					gotoTargets[t.Label.Name] = true
+				}
+			}
+			return true
+		})
+
+		states := 0
+		for label := range gotoTargets {
+			if labels[label] {
+				states++
+			}
+		}
+		if states > 0 && states >= minStates {
+			functions = append(functions, bblock.QualifiedFuncName(funcDecl))
+		}
+	}
+	return functions, nil
+}
+
+// SwitchDominatedFunctions returns the names of functions in srcFile where a single switch or
+// type switch statement contributes more than ratio of the cyclomatic complexity, as counted by
+// SwitchComplexityContribution. Such functions are candidates for polymorphism or table-driven code.
+func SwitchDominatedFunctions(srcFile []byte, ratio float64) ([]string, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	complexityFunctions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	complexities := map[int]int{}
+	for _, function := range complexityFunctions {
+		complexities[function.Line] = function.Complexity
+	}
+
+	functions := []string{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		complexity := complexities[fileSet.Position(funcDecl.Pos()).Line]
+		if complexity == 0 {
+			continue
+		}
+
+		maxContribution := 0
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			contribution := 0
+			switch t := node.(type) {
+			case *ast.FuncLit:
+				return false //Analysed as a function of its own.
+			case *ast.SwitchStmt:
+				contribution = switchContribution(t.Body)
+			case *ast.TypeSwitchStmt:
+				contribution = switchContribution(t.Body)
+			}
+			if contribution > maxContribution {
+				maxContribution = contribution
+			}
+			return true
+		})
+		if float64(maxContribution) > ratio*float64(complexity) {
+			functions = append(functions, bblock.QualifiedFuncName(funcDecl))
+		}
+	}
+	return functions, nil
+}
+
+const (
+	mixedConcernMinLines     = 30 //Minimum number of lines in a function mixing concerns.
+	mixedConcernMinDiversity = 3  //Minimum number of distinct control-structure types in a function mixing concerns.
+)
+
+// MixedConcernFunctions returns the names of functions in srcFile being both long and
+// using many distinct control-structure types, suggesting they handle several concerns
+// and should be split. A function is flagged when it spans at least 30 lines and uses
+// at least 3 of the control-structure types counted by bblock.BlockTypeDiversity.
+func MixedConcernFunctions(srcFile []byte) ([]string, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+	basicBlocks, err := bblock.GetBasicBlocksFromSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := []string{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		startLine := fileSet.Position(funcDecl.Pos()).Line
+		endLine := fileSet.Position(funcDecl.End()).Line
+		if endLine-startLine+1 < mixedConcernMinLines {
+			continue
+		}
+
+		var functionBlocks []*bblock.BasicBlock
+		for _, basicBlock := range basicBlocks {
+			if basicBlock.EndLine >= startLine && basicBlock.EndLine <= endLine {
+				functionBlocks = append(functionBlocks, basicBlock)
+			}
+		}
+		if bblock.BlockTypeDiversity(functionBlocks) >= mixedConcernMinDiversity {
+			functions = append(functions, bblock.QualifiedFuncName(funcDecl))
+		}
+	}
+	return functions, nil
+}
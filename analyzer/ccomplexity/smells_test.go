@@ -0,0 +1,230 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestDuplicateCases(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_duplicatecases.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caseGroups, err := DuplicateCases(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctCaseGroups := []CaseGroup{
+		CaseGroup{Function: "weekday", SwitchLine: 13, CaseLines: []int{18, 21}},
+	}
+	if !reflect.DeepEqual(caseGroups, correctCaseGroups) {
+		t.Errorf("Duplicate cases should be %+v, and not %+v!\n", correctCaseGroups, caseGroups)
+	}
+
+	//Switch without any identical case bodies.
+	srcFile, err = ioutil.ReadFile("./testcode/_switcher.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caseGroups, err = DuplicateCases(srcFile); err != nil {
+		t.Fatal(err)
+	} else if len(caseGroups) != 0 {
+		t.Errorf("No duplicate cases should be found, but found %+v!\n", caseGroups)
+	}
+}
+
+func TestCompoundLines(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_compoundlines.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := CompoundLines(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctLines := []int{9, 16}
+	if !reflect.DeepEqual(lines, correctLines) {
+		t.Errorf("Compound lines should be %v, and not %v!\n", correctLines, lines)
+	}
+}
+
+func TestSwitchesMissingDefault(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_missingdefault.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := SwitchesMissingDefault(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctLines := []int{18}
+	if !reflect.DeepEqual(lines, correctLines) {
+		t.Errorf("Switches missing default should be on lines %v, and not %v!\n", correctLines, lines)
+	}
+}
+
+func TestIdenticalBranches(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_identicalbranches.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := IdenticalBranches(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctLines := []int{9, 17, 38}
+	if !reflect.DeepEqual(lines, correctLines) {
+		t.Errorf("Identical branches should be on lines %v, and not %v!\n", correctLines, lines)
+	}
+}
+
+func TestLongIfLadders(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_ifladder.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		minBranches int
+		lines       []int
+	}{
+		{4, []int{9}},
+		{5, []int{9}},
+		{6, []int{}},
+		{3, []int{9, 24}},
+	}
+
+	for _, testCase := range testCases {
+		lines, err := LongIfLadders(srcFile, testCase.minBranches)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(lines, testCase.lines) {
+			t.Errorf("If ladders with at least %d branches should be on lines %v, and not %v!\n",
+				testCase.minBranches, testCase.lines, lines)
+		}
+	}
+}
+
+func TestSwitchDominatedFunctions(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_switchdominated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		ratio     float64
+		functions []string
+	}{
+		{0.9, []string{}},
+		{0.6, []string{"opcode"}},                    //Switch contributes 5 of 6.
+		{0.4, []string{"opcode", "describe"}},        //Type switch contributes 2 of 4.
+		{0.2, []string{"opcode", "run", "describe"}}, //Switch in loop contributes 1 of 4.
+	}
+
+	for _, testCase := range testCases {
+		functions, err := SwitchDominatedFunctions(srcFile, testCase.ratio)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(functions, testCase.functions) {
+			t.Errorf("Switch dominated functions with ratio %.1f should be %v, and not %v!\n",
+				testCase.ratio, testCase.functions, functions)
+		}
+	}
+}
+
+func TestGotoStateMachines(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_statemachine.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		minStates int
+		functions []string
+	}{
+		{5, []string{}},
+		{3, []string{"lex"}},          //States start, number, word and done.
+		{1, []string{"lex", "retry"}}, //Labels targeted by break, continue and closures are not states.
+	}
+
+	for _, testCase := range testCases {
+		functions, err := GotoStateMachines(srcFile, testCase.minStates)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(functions, testCase.functions) {
+			t.Errorf("Goto state machines with %d states should be %v, and not %v!\n",
+				testCase.minStates, testCase.functions, functions)
+		}
+	}
+}
+
+func TestMixedConcernFunctions(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_mixedconcerns.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	functions, err := MixedConcernFunctions(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctFunctions := []string{"process"}
+	if !reflect.DeepEqual(functions, correctFunctions) {
+		t.Errorf("Functions mixing concerns should be %v, and not %v!\n", correctFunctions, functions)
+	}
+}
+
+func TestUnbalancedBranches(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_unbalanced.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	branches, err := UnbalancedBranches(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	correctBranches := map[string]int{"main": 0, "clamp": 2, "sign": 0, "grade": 1}
+	if !reflect.DeepEqual(branches, correctBranches) {
+		t.Errorf("If statements without else should be %v, and not %v!\n", correctBranches, branches)
+	}
+}
+
+func TestArrowCodeFunctions(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_arrowcode.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		maxDepth  int
+		functions []string
+	}{
+		{4, []string{}},
+		{3, []string{"arrow"}},
+		{2, []string{"arrow", "stepped"}},
+		{1, []string{"arrow", "stepped"}},
+	}
+
+	for _, testCase := range testCases {
+		functions, err := ArrowCodeFunctions(srcFile, testCase.maxDepth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(functions, testCase.functions) {
+			t.Errorf("Arrow code functions with max depth %d should be %v, and not %v!\n",
+				testCase.maxDepth, testCase.functions, functions)
+		}
+	}
+}
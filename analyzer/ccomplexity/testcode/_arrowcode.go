@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(arrow([]int{1, 2}, 1), stepped(3), shallow(2))
+}
+
+// arrow nests every control structure in the previous one.
+func arrow(values []int, limit int) int {
+	count := 0
+	if len(values) > 0 {
+		for _, value := range values {
+			if value > 0 {
+				switch {
+				case value > limit:
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// stepped is as deep as arrow, but the nesting does not grow statement by statement.
+func stepped(n int) int {
+	if n > 0 {
+		if n > 1 {
+			n--
+		}
+		for i := 0; i < n; i++ {
+			if i%2 == 0 {
+				if i > 2 {
+					n--
+				}
+			}
+		}
+	}
+	return n
+}
+
+// shallow has else-if arms at the same depth.
+func shallow(n int) string {
+	if n < 0 {
+		return "negative"
+	} else if n == 0 {
+		return "zero"
+	} else if n < 10 {
+		return "small"
+	}
+	return "large"
+}
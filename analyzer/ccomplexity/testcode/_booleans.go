@@ -0,0 +1,39 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func single(a bool) int {
+	if a {
+		return 1
+	}
+	return 0
+}
+
+func triple(a, b, c bool) int {
+	if a && b && c {
+		return 1
+	}
+	return 0
+}
+
+func nested(a, b, c bool) int {
+	count := 0
+	for i := 0; i < 3 && (a || b && c); i++ {
+		count++
+	}
+	return count
+}
+
+func main() {
+	both := single(1 > 0) == 1 && triple(true, true, false) == 0
+	either := func(a, b bool) bool {
+		if a || b {
+			return true
+		}
+		return false
+	}
+	fmt.Println(both, either(false, true), nested(false, true, true))
+}
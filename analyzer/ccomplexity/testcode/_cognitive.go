@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func nested(a, b, c bool) string {
+	if a {
+		if b {
+			if c {
+				return "abc"
+			}
+		}
+	}
+	return ""
+}
+
+func guarded(a, b, c bool) string {
+	if !a {
+		return ""
+	}
+	if !b {
+		return ""
+	}
+	if !c {
+		return ""
+	}
+	return "abc"
+}
+
+func flatSwitch(x int) string {
+	switch x {
+	case 1:
+		return "one"
+	case 2:
+		return "two"
+	case 3:
+		return "three"
+	default:
+		return "many"
+	}
+}
+
+func conditions(a, b, c, d bool) bool {
+	if a && b || c && d {
+		fmt.Println("mixed")
+	} else if a && (b && c) {
+		fmt.Println("all")
+	} else {
+		fmt.Println("none")
+	}
+	return !(a || b) && c
+}
+
+func loops(matrix [][]int) int {
+	count := 0
+outer:
+	for i := 0; i < len(matrix); i++ {
+		for _, value := range matrix[i] {
+			if value < 0 {
+				continue outer
+			}
+			count += value
+		}
+	}
+	return count
+}
+
+func closure(values []int) func() int {
+	return func() int {
+		if len(values) == 0 {
+			return 0
+		}
+		return values[0]
+	}
+}
+
+func factorial(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * factorial(n-1)
+}
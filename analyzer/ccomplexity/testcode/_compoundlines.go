@@ -0,0 +1,18 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	for i := 0; i < 10; i++ { if i%2 == 0 {
+		fmt.Println("Even")
+	} else {
+		fmt.Println("Odd")
+	} }
+
+	for i := 0; i < 3; i++ {
+		switch i { case 0: fmt.Println("Zero"); default: for j := 0; j < i; j++ { fmt.Println(j) } }
+	}
+}
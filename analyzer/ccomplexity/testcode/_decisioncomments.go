@@ -0,0 +1,38 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func classify(n int) string {
+	// Negative numbers have no parity.
+	if n < 0 {
+		return "negative"
+	}
+	switch n % 2 {
+	case 0: // Even numbers.
+		return "even"
+	case 1:
+		return "odd"
+	default:
+		return "unknown"
+	}
+}
+
+func sum(numbers []int) int {
+	total := 0
+	for _, number := range numbers {
+		total += number
+	}
+	/* Adds the first numbers
+	   once more. */
+	for i := 0; i < 2 && i < len(numbers); i++ {
+		total += numbers[i]
+	}
+	return total
+}
+
+func main() {
+	fmt.Println(classify(3), sum([]int{1, 2, 3}))
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(safeDivide(4, 0))
+	fmt.Println(checkedDivide(4, 0))
+	fmt.Println(guardedDivide(4, 0))
+	fmt.Println(testedDivide(4, 0))
+}
+
+func safeDivide(a, b int) int {
+	defer func() {
+		r := recover()
+		fmt.Println("Recovered:", r)
+	}()
+	return a / b
+}
+
+func checkedDivide(a, b int) int {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Recovered:", r)
+		} else {
+			fmt.Println("No panic")
+		}
+	}()
+	defer func() {
+		fmt.Println("Done")
+	}()
+	return a / b
+}
+
+func guardedDivide(a, b int) int {
+	defer func() {
+		if recover() != nil {
+			fmt.Println("Recovered")
+		}
+	}()
+	return a / b
+}
+
+func testedDivide(a, b int) int {
+	defer func() {
+		r := recover()
+		if r != nil {
+			fmt.Println("Recovered:", r)
+		} else {
+			fmt.Println("No panic")
+		}
+	}()
+	return a / b
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func empty() {
+}
+
+func straight(a, b int) int {
+	sum := a + b
+	product := a * b
+	fmt.Println(sum, product)
+	return sum + product
+}
+
+func dense(x int) string {
+	if x < 0 {
+		return "negative"
+	}
+	switch {
+	case x == 0:
+		return "zero"
+	case x < 10:
+		return "small"
+	default:
+		return "large"
+	}
+}
+
+func long(values []int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	count := len(values)
+	mean := total / count
+	fmt.Println("Total", total)
+	fmt.Println("Mean", mean)
+	return mean
+}
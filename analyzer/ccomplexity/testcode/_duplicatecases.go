@@ -0,0 +1,27 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(weekday(6))
+}
+
+func weekday(day int) string {
+	switch day {
+	case 1:
+		return "Monday"
+	case 2:
+		return "Tuesday"
+	case 6:
+		fmt.Println("Weekend")
+		return "Weekend"
+	case 7:
+		fmt.Println("Weekend")
+		return "Weekend"
+	default:
+		return "Workday"
+	}
+}
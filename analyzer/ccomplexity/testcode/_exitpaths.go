@@ -0,0 +1,34 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+func main() {
+	fmt.Println(lookup(map[string]int{"a": 1}, "a"))
+	fmt.Println(guarded(func() { panic("boom") }))
+}
+
+func lookup(values map[string]int, key string) (int, error) {
+	if key == "" {
+		return 0, errors.New("empty key")
+	}
+	value, ok := values[key]
+	if !ok {
+		return 0, fmt.Errorf("no key %s", key)
+	}
+	return value, nil
+}
+
+func guarded(work func()) (err error) {
+	defer func() {
+		r := recover()
+		err = fmt.Errorf("recovered: %v", r)
+	}()
+	work()
+	return nil
+}
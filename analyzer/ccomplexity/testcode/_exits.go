@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+func parse(s string) int {
+	if s == "" {
+		panic("empty")
+	}
+	if s == "0" {
+		return 0
+	}
+	return len(s)
+}
+
+func run(args []string) {
+	if len(args) == 0 {
+		fmt.Println("usage")
+		os.Exit(2)
+	}
+	if args[0] == "-" {
+		log.Fatal("no input")
+	}
+	fmt.Println(args)
+}
+
+func serve(requests chan int) {
+	for {
+		request := <-requests
+		if request < 0 {
+			return
+		}
+		fmt.Println(request)
+	}
+}
+
+func drain(values chan int) {
+	for {
+		if _, ok := <-values; !ok {
+			break
+		}
+	}
+}
+
+func check(ok bool) {
+	defer func() {
+		if recover() != nil {
+			panic("again")
+		}
+	}()
+	if ok {
+		return
+	} else {
+		panic("failed")
+	}
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func main() {
+	fmt.Println(parse("1"), sign(1))
+	run(os.Args)
+	check(true)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func label(n int) string {
+	if n > 0 {
+		return "number"
+	} else {
+		return "number"
+	}
+}
+
+func scale(n int) int {
+	if n%2 == 0 {
+		n = n * 2
+		fmt.Println(n)
+	} else {
+		n   =   n*2
+		fmt.Println(n)
+	}
+	return n
+}
+
+func sign(n int) string {
+	if n > 0 {
+		return "positive"
+	} else {
+		return "negative"
+	}
+}
+
+func bucket(n int) string {
+	if n < 10 {
+		return "small"
+	} else if n < 100 {
+		return "large"
+	} else {
+		return "large"
+	}
+}
+
+func noop(n int) {
+	if n > 0 {
+	} else {
+	}
+}
+
+func main() {
+	fmt.Println(label(1), scale(2), sign(3), bucket(4))
+	noop(5)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func grade(score int) string {
+	if score >= 90 {
+		return "A"
+	} else if score >= 80 {
+		return "B"
+	} else if score >= 70 {
+		return "C"
+	} else if score >= 60 {
+		return "D"
+	} else {
+		return "F"
+	}
+}
+
+func main() {
+	score := 75
+	if score > 100 {
+		fmt.Println("Invalid score")
+	} else if score < 0 {
+		fmt.Println("Negative score")
+	} else {
+		fmt.Println(grade(score))
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(dayName(3), kind(3.5))
+}
+
+func dayName(day int) string {
+	var name string
+	switch day {
+	case 1:
+		name = "Monday"
+	case 2:
+		name = "Tuesday"
+	case 3:
+		name = "Wednesday"
+	case 4:
+		name = "Thursday"
+	case 5:
+		name = "Friday"
+	case 6, 7:
+		name = "Weekend"
+	default:
+		name = "Invalid day"
+	}
+	return name
+}
+
+func kind(value interface{}) string {
+	result := "unknown"
+	switch value.(type) {
+	case int:
+		result = "int"
+	case float64:
+		result = "float64"
+	}
+	return result
+}
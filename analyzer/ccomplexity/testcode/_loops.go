@@ -0,0 +1,34 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	matrix := [][]int{{1, 2}, {3, 4}}
+	fmt.Println(sum(matrix), countdown(3))
+}
+
+func sum(matrix [][]int) int {
+	total := 0
+	for i := 0; i < len(matrix); i++ {
+		for _, value := range matrix[i] {
+			total += value
+		}
+	}
+	return total
+}
+
+func countdown(n int) int {
+	for {
+		for n%2 == 0 {
+			n--
+		}
+		if n <= 1 {
+			break
+		}
+		n--
+	}
+	return n
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	number := 3
+
+	switch number {
+	case 0:
+		fmt.Println("Zero")
+	default:
+		fmt.Println("Not zero")
+	}
+
+	switch number {
+	case 1:
+		fmt.Println("One")
+	case 2:
+		fmt.Println("Two")
+	}
+
+	var x interface{} = number
+	switch x.(type) {
+	case int:
+		fmt.Println("Type is int")
+	}
+}
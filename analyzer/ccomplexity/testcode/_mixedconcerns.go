@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	fmt.Println(process([]string{"a", "bb", "ccc"}), pad("a"), describe(3))
+}
+
+// process parses, validates, transforms and reports in one function.
+func process(lines []string) string {
+	var result []string
+	longest := 0
+	for i := 0; i < len(lines); i++ {
+		if len(lines[i]) > longest {
+			longest = len(lines[i])
+		} else {
+			longest += 0
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		switch len(lines[i]) {
+		case 0:
+			result = append(result, "<empty>")
+		case longest:
+			result = append(result, strings.ToUpper(lines[i]))
+		default:
+			result = append(result, lines[i])
+		}
+	}
+
+	var report string
+	if len(result) > 2 {
+		report = strings.Join(result, ", ")
+	} else {
+		report = strings.Join(result, " ")
+	}
+	fmt.Println("processed", len(result), "lines")
+	fmt.Println("longest line has", longest, "characters")
+	return report
+}
+
+// pad is long, but does a single thing.
+func pad(s string) string {
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	s = s + " "
+	return s
+}
+
+// describe uses several control structures, but is short.
+func describe(n int) string {
+	var s string
+	for i := 0; i < n; i++ {
+		switch {
+		case i%2 == 0:
+			s += "even "
+		default:
+			s += "odd "
+		}
+	}
+	if n > 2 {
+		s += "many"
+	} else {
+		s += "few"
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func sequential(a, b, c bool) {
+	if a {
+		fmt.Println("a")
+	}
+	if b {
+		fmt.Println("b")
+	}
+	if c {
+		fmt.Println("c")
+	}
+}
+
+func branches(a, b bool) {
+	if a {
+		fmt.Println("a")
+	} else {
+		fmt.Println("not a")
+	}
+	if b {
+		fmt.Println("b")
+	}
+}
+
+func loop(n int) {
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			fmt.Println("even")
+		}
+	}
+	fmt.Println("done")
+}
+
+func nestedLoops(n int) {
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			fmt.Println(i, j)
+		}
+	}
+}
+
+func spin() {
+	for {
+		fmt.Println("spinning")
+	}
+}
+
+func switchWithoutDefault(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	case 2:
+		fmt.Println("two")
+	case 3:
+		fmt.Println("three")
+	}
+	fmt.Println("done")
+}
+
+func switchWithDefault(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	case 2:
+		fmt.Println("two")
+	default:
+		fmt.Println("other")
+	}
+	fmt.Println("done")
+}
+
+func switchFallthrough(x int, a bool) {
+	if a {
+		fmt.Println("a")
+	}
+	switch x {
+	case 1:
+		fmt.Println("one")
+		fallthrough
+	case 2:
+		fmt.Println("two")
+	case 3:
+		fmt.Println("three")
+	}
+	fmt.Println("done")
+}
+
+func main() {
+	sequential(true, false, true)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func g() int { return 1 }
+
+func h() int { return 2 }
+
+func sum() int { return g() + h() }
+
+func max[T int | float64](a, b T) T { return a + b - min(a, b) }
+
+func double(n int) int { n *= 2; return n }
+
+func show() { fmt.Println(sum()) }
+
+func noop() {}
+
+func apply() int { return func() int { return g() }() }
+
+func abs(x int) int { if x < 0 { return -x }; return x }
+
+func pick(a, b bool) int { if a && b { return 1 } else if a { return 2 }; return 3 }
+
+func both() { g(); h() }
+
+func main() {
+	fmt.Println(max(g(), h()), double(3))
+	show()
+	noop()
+	fmt.Println(apply())
+	fmt.Println(abs(-1), pick(true, false))
+	both()
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(sum([]int{1, -2, 3}))
+}
+
+func sum(xs []int) int {
+	t := 0
+	for _, x := range xs {
+		if x > 0 {
+			t += x
+		}
+	}
+	return t
+}
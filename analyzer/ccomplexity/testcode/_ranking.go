@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(sign(-1), countdown(3), classify(2))
+}
+
+func sign(x int) string {
+	var s string
+	if x < 0 {
+		s = "negative"
+	} else {
+		s = "not negative"
+	}
+	return s
+}
+
+func countdown(n int) int {
+	for n > 0 {
+		if n%2 == 0 {
+			n -= 2
+		} else {
+			n--
+		}
+	}
+	return n
+}
+
+func classify(x int) string {
+	switch x {
+	case 0:
+		return "zero"
+	case 1:
+		return "one"
+	case 2:
+		return "two"
+	}
+	return "many"
+}
+
+func noop() {
+}
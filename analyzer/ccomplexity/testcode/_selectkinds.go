@@ -0,0 +1,58 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func blocking(numbers, words chan int) int {
+	select {
+	case number := <-numbers:
+		return number
+	case word := <-words:
+		return -word
+	}
+}
+
+func polling(numbers, words chan int) int {
+	select {
+	case number := <-numbers:
+		return number
+	case word := <-words:
+		return -word
+	default:
+		return 0
+	}
+}
+
+func defaultOnly(numbers chan int) int {
+	count := 0
+	select {
+	default:
+		count++
+	}
+	return count
+}
+
+func forever() {
+	select {}
+}
+
+func receiveLoop(numbers chan int) {
+	for {
+		select {
+		case number := <-numbers:
+			fmt.Println(number)
+		default:
+			return
+		}
+	}
+}
+
+func main() {
+	numbers, words := make(chan int, 1), make(chan int, 1)
+	numbers <- 1
+	fmt.Println(blocking(numbers, words), polling(numbers, words), defaultOnly(numbers))
+	receiveLoop(numbers)
+	forever()
+}
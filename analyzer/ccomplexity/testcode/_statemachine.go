@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+// lex is a goto based state machine with four states.
+func lex(input string) (tokens int) {
+	i := 0
+start:
+	if i == len(input) {
+		goto done
+	}
+	if input[i] >= '0' && input[i] <= '9' {
+		goto number
+	}
+	goto word
+number:
+	for i < len(input) && input[i] >= '0' && input[i] <= '9' {
+		i++
+	}
+	tokens++
+	goto start
+word:
+	for i < len(input) && (input[i] < '0' || input[i] > '9') {
+		i++
+	}
+	tokens++
+	goto start
+done:
+	return tokens
+}
+
+// retry jumps back to a single label.
+func retry(attempts int) {
+again:
+	attempts--
+	if attempts > 0 {
+		goto again
+	}
+}
+
+// scan has labels targeted by break and continue only.
+func scan(rows [][]int) {
+outer:
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell < 0 {
+				continue outer
+			}
+			if cell == 0 {
+				break outer
+			}
+		}
+	}
+}
+
+// nested keeps the labels of the function literal apart from its own.
+func nested() {
+	step := func(n int) int {
+	loop:
+		if n > 0 {
+			n--
+			goto loop
+		}
+		return n
+	}
+	fmt.Println(step(3))
+}
+
+func main() {
+	fmt.Println(lex("abc 123"))
+	retry(3)
+	scan(nil)
+	nested()
+}
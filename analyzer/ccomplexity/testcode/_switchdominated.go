@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func opcode(code int) string {
+	switch code {
+	case 0:
+		return "nop"
+	case 1:
+		return "load"
+	case 2:
+		return "store"
+	case 3, 4:
+		return "jump"
+	case 5:
+		return "call"
+	default:
+		return "unknown"
+	}
+}
+
+func run(codes []int) {
+	for i := 0; i < len(codes); i++ {
+		switch codes[i] {
+		case 0:
+			continue
+		default:
+			fmt.Println(opcode(codes[i]))
+		}
+	}
+}
+
+func describe(value interface{}) string {
+	total := 0
+	for i := 0; i < 3; i++ {
+		total += i
+	}
+	switch value.(type) {
+	case int:
+		return "int"
+	case string:
+		return "string"
+	}
+	return fmt.Sprint(total)
+}
+
+func main() {
+	run([]int{0, 1, 2})
+	fmt.Println(describe(1))
+	fmt.Println(handlers()["name"](1))
+}
+
+//The switch belongs to the function literal, analysed as a function of its own.
+func handlers() map[string]func(int) string {
+	return map[string]func(int) string{
+		"name": func(code int) string {
+			switch code {
+			case 0:
+				return "zero"
+			case 1:
+				return "one"
+			case 2:
+				return "two"
+			}
+			return "other"
+		},
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	if got := sum(1, 2); got != 3 {
+		t.Errorf("Sum should be 3, but is %d", got)
+	} else {
+		t.Log("Sum is 3")
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sum(1, 2)
+	}
+}
+
+func ExampleSum() {
+	fmt.Println(sum(1, 2))
+	// Output: 3
+}
+
+func FuzzSum(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a, b int) {
+		sum(a, b)
+	})
+}
+
+func Testify(t *testing.T) {
+}
+
+func TestHelper(s string) {
+}
+
+func sum(a, b int) int {
+	return a + b
+}
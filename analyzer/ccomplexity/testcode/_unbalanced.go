@@ -0,0 +1,44 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(clamp(12), sign(-3), grade(75))
+}
+
+func clamp(x int) int {
+	if x < 0 {
+		x = 0
+	}
+	if x > 10 {
+		x = 10
+	}
+	return x
+}
+
+func sign(x int) string {
+	if x < 0 {
+		return "negative"
+	} else {
+		return "not negative"
+	}
+}
+
+func grade(score int) string {
+	result := "fail"
+	if score >= 90 {
+		result = "excellent"
+	} else if score >= 70 {
+		result = "good"
+	}
+	check := func() {
+		if score > 100 {
+			panic("invalid score")
+		}
+	}
+	check()
+	return result
+}
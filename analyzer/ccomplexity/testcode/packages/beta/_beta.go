@@ -0,0 +1,17 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package beta
+
+func monthNumberToString(month int) string {
+	switch month {
+	case 1:
+		return "January"
+	case 2:
+		return "February"
+	case 3:
+		return "March"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package store
+
+import "strconv"
+
+func parseAll(texts []string) ([]int, error) {
+	var numbers []int
+	for i := 0; i < len(texts); i++ {
+		number, err := strconv.Atoi(texts[i])
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+func sum(texts []string) (int, error) {
+	numbers, err := parseAll(texts)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for i := 0; i < len(numbers); i++ {
+		total += numbers[i]
+	}
+	return total, nil
+}
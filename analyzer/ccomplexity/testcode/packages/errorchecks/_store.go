@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package store
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type Store struct {
+	values map[string]int
+}
+
+func Open(path string) (*Store, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	store := &Store{values: map[string]int{}}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		value, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("bad value %q: %v", fields[1], err)
+		}
+		store.values[fields[0]] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (store *Store) Save(path string, keys []string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(keys); i++ {
+		if _, err := fmt.Fprintf(file, "%s %d\n", keys[i], store.values[keys[i]]); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	return file.Close()
+}
+
+func (store *Store) Level(key string) string {
+	switch value := store.values[key]; {
+	case value > 100:
+		return "high"
+	case value > 10:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func (store *Store) Load(key, text string) error {
+	value, err := strconv.Atoi(text)
+	if key == "" {
+		return fmt.Errorf("empty key")
+	} else if err != nil {
+		return err
+	}
+	store.values[key] = value
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.21.12
+// source: greeter.proto
+
+package greeter
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+type HelloRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string
+}
+
+func (x *HelloRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_greeter_proto_msgTypes[0]
+	return mi.MessageOf(x)
+}
+
+func file_greeter_proto_exporter(v interface{}, i int) interface{} {
+	switch v := v.(*HelloRequest); i {
+	case 0:
+		return &v.state
+	case 1:
+		return &v.sizeCache
+	case 2:
+		return &v.unknownFields
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package greeter
+
+// server implements the greeter service, embedding the generated base implementation.
+type server struct {
+	UnimplementedGreeterServer
+}
+
+func (s *server) mustEmbedUnimplementedGreeterServer() {}
+
+func greeting(name string) string {
+	switch name {
+	case "":
+		name = "stranger"
+	}
+	return "Hello, " + name
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+func sum(values []int) int {
+	total := 0
+	for i := 0; i < len(values); i++ {
+		total += values[i]
+	}
+	return total
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package main
+
+import "fmt"
+
+func count(yield func(int) bool) {
+	for i := 0; i < 3 && yield(i); i++ {
+	}
+}
+
+func main() {
+	total := 0
+	for i := range count {
+		total += i
+	}
+	fmt.Println(total)
+}
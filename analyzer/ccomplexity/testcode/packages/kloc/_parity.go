@@ -0,0 +1,24 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package kloc
+
+import "strings"
+
+// usage is printed on wrong input.
+const usage = `usage:
+	parity <number>`
+
+// parity returns "even" or "odd".
+func parity(n int) string {
+	/* The sign does not
+	   change parity. */
+	if n%2 == 0 {
+		return "even"
+	}
+	return "odd"
+}
+
+func shout(s string) string {
+	return strings.ToUpper(s) // Trailing comments are part of the line.
+}
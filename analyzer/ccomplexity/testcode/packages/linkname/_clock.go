@@ -0,0 +1,33 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package linkname
+
+import (
+	"errors"
+	_ "unsafe"
+)
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+//go:linkname cputicks runtime.cputicks
+func cputicks() int64
+
+func since(start int64) (int64, error) {
+	err := check(start)
+	if err != nil {
+		return 0, err
+	}
+	if now := nanotime(); now > start {
+		return now - start, nil
+	}
+	return 0, nil
+}
+
+func check(start int64) error {
+	if start < 0 {
+		return errors.New("negative start")
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build plan9
+
+package platform
+
+func (r *reader) separator() string {
+	return "/"
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package platform
+
+import "strings"
+
+func (r *reader) separator() string {
+	separator := "\\"
+	if strings.Contains(r.path, "/") {
+		separator = "/"
+	} else {
+		separator = "\\"
+	}
+	return separator
+}
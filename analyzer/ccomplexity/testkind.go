@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TestKind is the kind of test function, as recognized by go test.
+type TestKind int
+
+//Test function kinds.
+const (
+	NOT_TEST TestKind = iota
+	TEST
+	BENCHMARK
+	EXAMPLE
+	FUZZ
+)
+
+var testKindStrings = [...]string{
+	NOT_TEST:  "NOT_TEST",
+	TEST:      "TEST",
+	BENCHMARK: "BENCHMARK",
+	EXAMPLE:   "EXAMPLE",
+	FUZZ:      "FUZZ",
+}
+
+func (kind TestKind) String() string {
+	return testKindStrings[kind]
+}
+
+// testFunctionKinds returns the kind of each test function in file, keyed
+// by the line of the function declaration.
+func testFunctionKinds(srcFile []byte) (map[int]TestKind, error) {
+	fileSet, file, err := parseSourceCode(srcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := map[int]TestKind{}
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+			if kind := getTestKind(funcDecl); kind != NOT_TEST {
+				kinds[fileSet.Position(funcDecl.Pos()).Line] = kind
+			}
+		}
+	}
+	return kinds, nil
+}
+
+// getTestKind classifies funcDecl by name and signature, TestXxx(*testing.T),
+// BenchmarkXxx(*testing.B), FuzzXxx(*testing.F) and ExampleXxx().
+func getTestKind(funcDecl *ast.FuncDecl) TestKind {
+	if funcDecl.Recv != nil || funcDecl.Type.Results != nil {
+		return NOT_TEST
+	}
+	params := funcDecl.Type.Params.List
+	name := funcDecl.Name.Name
+
+	switch {
+	case isTestName(name, "Example"):
+		if len(params) == 0 {
+			return EXAMPLE
+		}
+	case isTestName(name, "Test"):
+		if isTestingParam(params, "T") {
+			return TEST
+		}
+	case isTestName(name, "Benchmark"):
+		if isTestingParam(params, "B") {
+			return BENCHMARK
+		}
+	case isTestName(name, "Fuzz"):
+		if isTestingParam(params, "F") {
+			return FUZZ
+		}
+	}
+	return NOT_TEST
+}
+
+// isTestName reports whether name is prefix, or prefix followed by a
+// character that is not a lower case letter, as in TestXxx.
+func isTestName(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+// isTestingParam reports whether params is a single parameter of type *testing.<typeName>.
+func isTestingParam(params []*ast.Field, typeName string) bool {
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return false
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	selector, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && pkg.Name == "testing" && selector.Sel.Name == typeName
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2015-2016 The GoAnalysis Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+package ccomplexity
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestTestFunctionKinds(t *testing.T) {
+	srcFile, err := ioutil.ReadFile("./testcode/_testfunctions_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	functions, err := GetCyclomaticComplexityFunctionLevel(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correctKinds := map[string]TestKind{
+		"TestSum":       TEST,
+		"BenchmarkSum":  BENCHMARK,
+		"ExampleSum":    EXAMPLE,
+		"FuzzSum":       FUZZ,
+		"FuzzSum$func1": NOT_TEST,
+		"Testify":       NOT_TEST,
+		"TestHelper":    NOT_TEST,
+		"sum":           NOT_TEST,
+	}
+
+	if len(functions) != len(correctKinds) {
+		t.Fatalf("Number of functions should be %d, but are %d!\n", len(correctKinds), len(functions))
+	}
+	for _, function := range functions {
+		if kind, ok := correctKinds[function.Name]; !ok || function.Kind != kind {
+			t.Errorf("Function %s should be of kind %s, but is of kind %s!\n", function.Name, kind, function.Kind)
+		}
+	}
+}